@@ -1,18 +1,55 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
 
 	"github.com/bordenet/genesis/genesis-validator/internal/validator"
 )
 
 func main() {
+	// Subcommands take precedence over flag parsing
+	if len(os.Args) > 1 && os.Args[1] == "lsp" {
+		runLSP(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "graph" {
+		runGraph(os.Args[2:])
+		return
+	}
+
 	// Parse command-line flags
 	verbose := flag.Bool("verbose", false, "Enable verbose output")
 	noPrompt := flag.Bool("no-prompt", false, "Disable LLM prompt generation")
+	promptProfile := flag.String("prompt-profile", validator.DefaultPromptProfile, "LLM prompt profile: "+strings.Join(validator.PromptProfiles(), ", ")+", or a .tmpl file")
+	promptOut := flag.String("prompt-out", "", "Write the LLM prompt to this file instead of stdout")
+	agentCmd := flag.String("agent-cmd", "", "Send fix prompts to this shell command and apply the unified diffs it prints")
+	agentURL := flag.String("agent-url", "", "Send fix prompts to this OpenAI-compatible API and apply the unified diffs it returns")
+	agentModel := flag.String("agent-model", "", "Model requested from -agent-url")
+	agentKeyEnv := flag.String("agent-key-env", "OPENAI_API_KEY", "Environment variable holding the API key sent to -agent-url")
+	patchOut := flag.String("patch-out", "", "Write every automatic fix to this file as a patch for git apply")
+	varsFile := flag.String("vars", "", "JSON file of template variable values substituted for {{PLACEHOLDERS}} in -patch-out")
+	promptTokens := flag.Int("prompt-tokens", validator.DefaultPromptTokens, "Token budget per LLM prompt; larger results are split into several prompts (0 for no limit)")
+	repoRootDir := flag.String("repo-root", "", "Repository root (default: nearest directory containing .git)")
 	genesisRoot := flag.String("genesis-root", "genesis", "Path to genesis directory")
+	staged := flag.Bool("staged", false, "Validate only links affected by staged changes")
+	rev := flag.String("rev", "", "Validate the files of a git revision (branch, tag, SHA or HEAD~N) instead of the working tree")
+	archive := flag.String("archive", "", "Validate the files of a .tar.gz, .tgz, .tar or .zip archive instead of the working tree")
+	watch := flag.Bool("watch", false, "Watch markdown files and re-run affected checks on change")
+	baselineFile := flag.String("baseline", "", "Ignore findings recorded in this baseline file")
+	writeBaseline := flag.String("write-baseline", "", "Record all current findings to this baseline file and exit")
+	configFile := flag.String("config", "", "Path to JSON config file (default: "+validator.DefaultConfigFile+" if present)")
+	untracked := flag.Bool("untracked", false, "Also validate untracked files that .gitignore does not exclude")
+	strict := flag.Bool("strict", false, "Require links to resolve relative to their file and stay in the repository (default: on when CI is set)")
+	external := flag.Bool("external", false, "Also check http(s) links outside this repository (needs network)")
+	referenceDocs := flag.String("reference-docs", "", "Comma-separated docs or globs parsed for template references")
 	help := flag.Bool("help", false, "Show help message")
 
 	flag.Parse()
@@ -22,43 +59,216 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Create configuration
+	// Track which flags were given so they override the config file
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	root, err := repoRoot(*repoRootDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to find repository root: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Create configuration: defaults, then config file, then flags
 	config := validator.DefaultConfig()
-	config.Verbose = *verbose
-	config.GeneratePrompt = !*noPrompt
-	config.GenesisRoot = *genesisRoot
-	config.TemplatesDir = *genesisRoot + "/templates"
-	config.StartHereFile = *genesisRoot + "/START-HERE.md"
-	config.ChecklistFile = *genesisRoot + "/CHECKLIST.md"
+	if err := loadConfigFile(config, *configFile, root); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if setFlags["repo-root"] || config.RepoRoot == "" {
+		config.RepoRoot = root
+	}
+	config.Verbose = config.Verbose || *verbose
+	if *noPrompt {
+		config.GeneratePrompt = false
+	}
+	if setFlags["prompt-profile"] {
+		config.PromptProfile = *promptProfile
+		if validator.IsPromptFile(*promptProfile) {
+			config.PromptProfile = anchorPath(config.RepoRoot, *promptProfile)
+		}
+	}
+	if setFlags["prompt-tokens"] {
+		config.PromptTokens = *promptTokens
+	}
+	if *agentCmd != "" || *agentURL != "" {
+		config.Agent.Command, config.Agent.URL = *agentCmd, *agentURL
+	}
+	if *agentModel != "" {
+		config.Agent.Model = *agentModel
+	}
+	config.Agent.APIKeyEnv = *agentKeyEnv
+	if setFlags["genesis-root"] {
+		genesis := anchorPath(config.RepoRoot, *genesisRoot)
+		config.GenesisRoot = genesis
+		config.TemplatesDir = genesis + "/templates"
+		config.StartHereFile = genesis + "/START-HERE.md"
+		config.ChecklistFile = genesis + "/CHECKLIST.md"
+		config.StepsDir = genesis + "/steps"
+		config.ManifestFile = genesis + "/template-manifest.json"
+		config.BaselineDir = genesis + "/examples/hello-world"
+		config.ReferenceDocs = validator.DefaultReferenceDocs(genesis)
+		config.Budgets = validator.DefaultBudgets(genesis)
+	}
+	if *untracked {
+		config.IncludeUntracked = true
+	}
+	if setFlags["strict"] {
+		config.Strict = *strict
+	}
+	if *external {
+		config.ExternalLinks.Enabled = true
+	}
+	if setFlags["reference-docs"] {
+		config.ReferenceDocs = nil
+		for _, doc := range strings.Split(*referenceDocs, ",") {
+			config.ReferenceDocs = append(config.ReferenceDocs, anchorPath(config.RepoRoot, doc))
+		}
+	}
 
-	// Run validation
-	v := validator.NewValidator(config)
-	result, err := v.Validate()
+	inputs := 0
+	for _, set := range []bool{*rev != "", *archive != "", *staged, *watch} {
+		if set {
+			inputs++
+		}
+	}
+	if inputs > 1 {
+		fmt.Fprintln(os.Stderr, "❌ -rev, -archive, -staged and -watch can't be combined")
+		os.Exit(1)
+	}
+	if config.Agent.Enabled() && inputs > 0 {
+		fmt.Fprintln(os.Stderr, "❌ Agent fixes apply to the working tree and can't be combined with -rev, -archive, -staged or -watch")
+		os.Exit(1)
+	}
+	if *patchOut != "" && *watch {
+		fmt.Fprintln(os.Stderr, "❌ -patch-out can't be combined with -watch")
+		os.Exit(1)
+	}
+
+	var vars map[string]string
+	if *varsFile != "" {
+		if *patchOut == "" {
+			fmt.Fprintln(os.Stderr, "❌ -vars needs -patch-out")
+			os.Exit(1)
+		}
+		if vars, err = validator.LoadVariables(*varsFile); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to load variables: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *watch {
+		runWatch(config)
+		return
+	}
+
+	// Read a revision or archive instead of the working tree
+	var fsys fs.FS
+	switch {
+	case *rev != "":
+		fsys, err = validator.NewGitRepo(config.RepoRoot).TreeFS(*rev)
+	case *archive != "":
+		fsys, err = validator.OpenArchive(*archive)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to read input: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Run validation, stopping on Ctrl-C
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	v := validator.NewValidatorWith(config, validator.Options{FS: fsys, Logger: verboseLogger(config.Verbose)})
+	var result *validator.ValidationResult
+	if *staged {
+		result, err = v.ValidateStaged(validator.NewGitRepo(config.RepoRoot))
+	} else {
+		result, err = v.ValidateContext(ctx)
+	}
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ Validation failed: %v\n", err)
 		os.Exit(1)
 	}
 
+	if *writeBaseline != "" {
+		baseline := validator.NewBaseline(result)
+		if err := baseline.Save(*writeBaseline); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to write baseline: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("📌 Wrote %d findings to baseline %s\n", len(baseline.Findings), *writeBaseline)
+		os.Exit(0)
+	}
+
+	var baseline *validator.Baseline
+	if *baselineFile != "" {
+		baseline, err = validator.LoadBaseline(*baselineFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to load baseline: %v\n", err)
+			os.Exit(1)
+		}
+		baseline.Apply(result)
+	}
+
+	// Let the agent fix what it can, then report on the patched tree
+	if config.Agent.Enabled() && (!result.IsValid() || result.HasWarnings()) {
+		if runAgent(ctx, v, config, result) {
+			if result, err = v.ValidateContext(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Validation failed: %v\n", err)
+				os.Exit(1)
+			}
+			if baseline != nil {
+				baseline.Apply(result)
+			}
+		}
+		fmt.Println()
+	}
+
 	// Print summary
 	fmt.Println(result.Summary())
 	fmt.Println()
 
-	// Print detailed results if verbose
-	if config.Verbose {
+	// Print detailed results if verbose, and always for staged changes, so a
+	// blocked commit shows what to fix
+	if config.Verbose || *staged {
 		printDetailedResults(result)
 	}
 
+	// Write the automatic fixes for review instead of applying them
+	if *patchOut != "" {
+		writePatch(ctx, v, result, *patchOut, vars)
+	}
+
 	// Generate LLM prompt if there are issues
 	if config.GeneratePrompt && (!result.IsValid() || result.HasWarnings()) {
 		promptGen := validator.NewPromptGenerator(config)
-		prompt := promptGen.GeneratePrompt(result)
+		prompts, err := promptGen.GeneratePrompts(result)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to generate prompt: %v\n", err)
+			os.Exit(1)
+		}
 
-		fmt.Println("=" + string(make([]byte, 79)))
-		fmt.Println("LLM PROMPT FOR FIXING ISSUES")
-		fmt.Println("=" + string(make([]byte, 79)))
-		fmt.Println()
-		fmt.Println(prompt)
+		if *promptOut != "" {
+			files, err := writePrompts(*promptOut, prompts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Failed to write prompt: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("📝 Wrote LLM prompt to %s\n", strings.Join(files, ", "))
+		} else {
+			for i, prompt := range prompts {
+				title := "LLM PROMPT FOR FIXING ISSUES"
+				if len(prompts) > 1 {
+					title += fmt.Sprintf(" (PART %d OF %d)", i+1, len(prompts))
+				}
+				fmt.Println("=" + string(make([]byte, 79)))
+				fmt.Println(title)
+				fmt.Println("=" + string(make([]byte, 79)))
+				fmt.Println()
+				fmt.Println(prompt)
+			}
+		}
 	}
 
 	// Exit with appropriate code
@@ -78,13 +288,45 @@ func printHelp() {
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  genesis-validator [options]")
+	fmt.Println("  genesis-validator lsp [-config FILE] [-repo-root DIR]  Run as a Language Server over stdio")
+	fmt.Println("  genesis-validator graph [graph options]  Print the markdown link graph")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  -verbose          Enable verbose output")
 	fmt.Println("  -no-prompt        Disable LLM prompt generation")
-	fmt.Println("  -genesis-root     Path to genesis directory (default: genesis)")
+	fmt.Println("  -prompt-profile P LLM prompt profile: " + strings.Join(validator.PromptProfiles(), ", ") + ", or a .tmpl file")
+	fmt.Println("  -prompt-out FILE  Write the LLM prompt to FILE (FILE-1, FILE-2, ... when split) instead of stdout")
+	fmt.Printf("  -prompt-tokens N  Token budget per LLM prompt; larger results are split (default: %d, 0 for no limit)\n", validator.DefaultPromptTokens)
+	fmt.Println("  -agent-cmd CMD    Send fix prompts to a shell command and apply the unified diffs it prints")
+	fmt.Println("  -agent-url URL    Send fix prompts to an OpenAI-compatible API (e.g. http://localhost:11434/v1)")
+	fmt.Println("  -agent-model M    Model requested from -agent-url")
+	fmt.Println("  -agent-key-env V  Environment variable holding the API key sent to -agent-url (default: OPENAI_API_KEY)")
+	fmt.Println("  -patch-out FILE   Write every automatic fix to FILE as a patch for git apply")
+	fmt.Println("  -vars FILE        JSON file of template variable values substituted for {{PLACEHOLDERS}} in -patch-out")
+	fmt.Println("  -repo-root DIR    Repository root (default: nearest directory above the working directory containing .git)")
+	fmt.Println("  -genesis-root     Path to genesis directory (default: genesis under the repository root)")
+	fmt.Println("  -staged           Validate only links affected by staged changes and list every finding (pre-commit)")
+	fmt.Println("  -rev REV          Validate a git revision (branch, tag, SHA or HEAD~N) without checking it out")
+	fmt.Println("  -archive FILE     Validate a .tar.gz, .tgz, .tar or .zip archive without extracting it")
+	fmt.Println("  -watch            Watch markdown files and re-run affected checks on change")
+	fmt.Println("  -baseline FILE    Ignore findings recorded in a baseline file")
+	fmt.Println("  -write-baseline FILE  Record all current findings to a baseline file")
+	fmt.Println("  -config FILE      JSON config file (default: .genesis-validator.json if present)")
+	fmt.Println("  -reference-docs   Comma-separated docs or globs parsed for template references")
+	fmt.Println("  -untracked        Also validate untracked files that .gitignore does not exclude")
+	fmt.Println("  -strict           Require links to resolve relative to their file and stay in the repo")
+	fmt.Println("                    (default: on when the CI environment variable is set; -strict=false disables)")
+	fmt.Println("  -external         Also check http(s) links outside this repository (needs network)")
 	fmt.Println("  -help             Show this help message")
 	fmt.Println()
+	fmt.Println("Graph Options:")
+	fmt.Println("  -format FORMAT    dot, mermaid or json (default: dot)")
+	fmt.Println("  -collapse         Collapse files into one node per directory")
+	fmt.Println("  -highlight-broken Draw broken links and missing targets in red")
+	fmt.Println("  -o FILE           Write the graph to FILE instead of stdout")
+	fmt.Println("  -config FILE      JSON config file")
+	fmt.Println("  -repo-root DIR    Repository root")
+	fmt.Println()
 	fmt.Println("Exit Codes:")
 	fmt.Println("  0 - All checks passed")
 	fmt.Println("  1 - Critical errors found (orphaned/missing files)")
@@ -94,6 +336,239 @@ func printHelp() {
 	fmt.Println("  genesis-validator")
 	fmt.Println("  genesis-validator -verbose")
 	fmt.Println("  genesis-validator -genesis-root /path/to/genesis")
+	fmt.Println("  genesis-validator -repo-root ../genesis")
+	fmt.Println("  genesis-validator -staged")
+	fmt.Println("  genesis-validator -rev v1.2.0")
+	fmt.Println("  genesis-validator -archive genesis-main.tar.gz")
+	fmt.Println("  genesis-validator -prompt-profile codex -prompt-out fix-prompt.md")
+	fmt.Println("  genesis-validator -agent-url http://localhost:11434/v1 -agent-model qwen2.5-coder")
+	fmt.Println("  genesis-validator -patch-out fixes.patch -vars project-vars.json")
+	fmt.Println("  genesis-validator -external")
+	fmt.Println("  genesis-validator -watch")
+	fmt.Println("  genesis-validator -write-baseline .genesis-baseline.json")
+	fmt.Println("  genesis-validator -baseline .genesis-baseline.json")
+	fmt.Println("  genesis-validator graph -collapse -highlight-broken | dot -Tsvg > links.svg")
+	fmt.Println("  genesis-validator graph -format mermaid -o docs/links.mmd")
+}
+
+// loadConfigFile applies the named config file, or the default config file
+// at the repository root when it exists and no file was named
+func loadConfigFile(config *validator.Config, path, root string) error {
+	if path == "" {
+		path = filepath.Join(root, validator.DefaultConfigFile)
+		if _, err := os.Stat(path); err != nil {
+			return nil
+		}
+	}
+	return validator.LoadConfigFile(path, config)
+}
+
+// runAgent asks the configured agent for patches and applies the ones that
+// reduce the findings, reporting whether any were applied
+func runAgent(ctx context.Context, v *validator.Validator, config *validator.Config, result *validator.ValidationResult) bool {
+	agent, err := validator.NewAgent(config.Agent, config.RepoRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to configure agent: %v\n", err)
+		os.Exit(1)
+	}
+
+	attempts, err := v.FixWithAgent(ctx, agent, result)
+	applied := false
+	for _, a := range attempts {
+		switch {
+		case a.Accepted:
+			applied = true
+			fmt.Printf("🤖 Part %d: applied patch to %s (findings %d → %d)\n", a.Part, strings.Join(a.Files, ", "), a.Before, a.After)
+		case a.Files != nil:
+			fmt.Printf("🤖 Part %d: discarded patch to %s: %s\n", a.Part, strings.Join(a.Files, ", "), a.Reason)
+		default:
+			fmt.Printf("🤖 Part %d: no patch applied: %s\n", a.Part, a.Reason)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Agent fixes failed: %v\n", err)
+		os.Exit(1)
+	}
+	return applied
+}
+
+// writePatch writes the automatic fixes for a result to path as a unified
+// diff, which is empty when there is nothing to fix
+func writePatch(ctx context.Context, v *validator.Validator, result *validator.ValidationResult, path string, vars map[string]string) {
+	patch, err := v.FixPatch(ctx, result, vars)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to collect fixes: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(path, []byte(patch.Patch), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to write patch: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(patch.Files) == 0 {
+		fmt.Printf("🩹 No automatic fixes available; wrote an empty patch to %s\n", path)
+	} else {
+		fmt.Printf("🩹 Wrote %d fixes to %d files in %s; review it, then run: git apply %s\n", len(patch.Applied), len(patch.Files), path, path)
+	}
+	for _, fix := range patch.Skipped {
+		fmt.Printf("   Skipped %s:%d: %s (overlaps another fix or no longer matches)\n", filepath.ToSlash(fix.File), fix.Line, fix.Title)
+	}
+	fmt.Println()
+}
+
+// writePrompts writes a single prompt to path, or several to numbered files
+// beside it (prompt-1.md, prompt-2.md, ...), and returns the files written
+func writePrompts(path string, prompts []string) ([]string, error) {
+	if len(prompts) == 1 {
+		return []string{path}, os.WriteFile(path, []byte(prompts[0]), 0644)
+	}
+
+	ext := filepath.Ext(path)
+	var files []string
+	for i, prompt := range prompts {
+		name := fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), i+1, ext)
+		if err := os.WriteFile(name, []byte(prompt), 0644); err != nil {
+			return files, err
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+// verboseLogger returns a logger printing progress messages to stdout when
+// verbose is set, and nil otherwise
+func verboseLogger(verbose bool) *slog.Logger {
+	if !verbose {
+		return nil
+	}
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+// repoRoot returns the -repo-root directory as an absolute path, or the
+// nearest directory above the working directory containing .git
+func repoRoot(dir string) (string, error) {
+	if dir != "" {
+		return filepath.Abs(dir)
+	}
+	return validator.FindRepoRoot(".")
+}
+
+// anchorPath makes a path given on the command line, which is relative to
+// the working directory, relative to the repository root. Paths outside the
+// repository are returned absolute.
+func anchorPath(root, p string) string {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return p
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return abs
+	}
+	return filepath.ToSlash(rel)
+}
+
+// runLSP serves editor diagnostics over stdio until the client exits
+func runLSP(args []string) {
+	flags := flag.NewFlagSet("lsp", flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to JSON config file (default: "+validator.DefaultConfigFile+" if present)")
+	repoRootDir := flags.String("repo-root", "", "Repository root (default: the client's workspace root)")
+	_ = flags.Parse(args)
+
+	root, err := repoRoot(*repoRootDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to find repository root: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Create configuration: defaults, then config file, then flags. Without a
+	// configured root the client's workspace root is used.
+	config := validator.DefaultConfig()
+	if err := loadConfigFile(config, *configFile, root); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if *repoRootDir != "" {
+		config.RepoRoot = root
+	}
+
+	server := validator.NewLSPServer(config)
+	if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ LSP server failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runGraph prints the markdown link graph in the requested format
+func runGraph(args []string) {
+	flags := flag.NewFlagSet("graph", flag.ExitOnError)
+	format := flags.String("format", "dot", "Output format: "+strings.Join(validator.GraphFormats, ", "))
+	collapse := flags.Bool("collapse", false, "Collapse files into one node per directory")
+	highlightBroken := flags.Bool("highlight-broken", false, "Draw broken links and missing targets in red")
+	output := flags.String("o", "", "Write the graph to this file instead of stdout")
+	configFile := flags.String("config", "", "Path to JSON config file (default: "+validator.DefaultConfigFile+" if present)")
+	repoRootDir := flags.String("repo-root", "", "Repository root (default: nearest directory containing .git)")
+	_ = flags.Parse(args)
+
+	root, err := repoRoot(*repoRootDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to find repository root: %v\n", err)
+		os.Exit(1)
+	}
+	config := validator.DefaultConfig()
+	if err := loadConfigFile(config, *configFile, root); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if *repoRootDir != "" || config.RepoRoot == "" {
+		config.RepoRoot = root
+	}
+
+	graph, err := validator.NewValidator(config).LinkGraph(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to build link graph: %v\n", err)
+		os.Exit(1)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		out, err = os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to create %s: %v\n", *output, err)
+			os.Exit(1)
+		}
+		defer func() { _ = out.Close() }()
+	}
+
+	opts := validator.GraphOptions{Format: *format, CollapseDirs: *collapse, HighlightBroken: *highlightBroken}
+	if err := graph.Write(out, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to write graph: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runWatch re-validates on every change until interrupted
+func runWatch(config *validator.Config) {
+	stop := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		close(stop)
+	}()
+
+	watcher := validator.NewWatcher(config, os.Stdout)
+	if err := watcher.Run(stop); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Watch failed: %v\n", err)
+		os.Exit(1)
+	}
 }
 
 func printDetailedResults(result *validator.ValidationResult) {
@@ -116,8 +591,10 @@ func printDetailedResults(result *validator.ValidationResult) {
 	if len(result.MissingFiles) > 0 {
 		fmt.Println("❌ Missing Files:")
 		for _, file := range result.MissingFiles {
-			docs := result.ReferencedFiles[file]
-			fmt.Printf("  ❌ %s (referenced in: %v)\n", file, docs)
+			fmt.Printf("  ❌ %s\n", file)
+			for _, ref := range result.References[file] {
+				fmt.Printf("     referenced at %s\n", ref)
+			}
 		}
 		fmt.Println()
 	}
@@ -51,7 +51,9 @@ cat > "$HOOK_FILE" << 'EOF'
 #!/bin/bash
 #
 # Genesis Pre-Commit Hook
-# Runs the Genesis validator to ensure template consistency before committing
+# Runs the Genesis validator against the staged changes before committing.
+# Only links in staged markdown files, and links into files renamed or
+# deleted by the commit, are checked.
 #
 
 set -e
@@ -88,9 +90,9 @@ if [ ! -x "$VALIDATOR_BIN" ]; then
     exit 0
 fi
 
-# Run the validator
+# Run the validator against the staged changes only
 cd "$REPO_ROOT"
-if "$VALIDATOR_BIN"; then
+if "$VALIDATOR_BIN" -staged -no-prompt; then
     echo ""
     echo -e "${GREEN}✅ Genesis validation passed!${NC}"
     echo ""
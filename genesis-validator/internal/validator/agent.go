@@ -0,0 +1,386 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// agentInstructions is appended to each prompt sent to an agent, so its
+// answer can be applied mechanically
+const agentInstructions = `
+
+---
+
+**Response format**: reply with a single unified diff that fixes the findings
+above, as produced by ` + "`git diff`" + `, with paths relative to the repository
+root prefixed a/ and b/, in one ` + "```diff" + ` block. Include at least
+three lines of unchanged context around each change. Do not include anything
+else.
+`
+
+// AgentConfig configures the opt-in agent that turns fix prompts into patches.
+// What runs and where keys are sent is never read from a config file, since
+// one committed to a repository would otherwise run on every validation.
+type AgentConfig struct {
+	Command   string `json:"-"`       // Shell command reading a prompt on stdin and writing a diff to stdout
+	URL       string `json:"-"`       // Base URL of an OpenAI-compatible API, e.g. http://localhost:11434/v1
+	Model     string `json:"model"`   // Model requested from URL
+	APIKeyEnv string `json:"-"`       // Environment variable holding the API key sent to URL, if set
+	Timeout   string `json:"timeout"` // Time allowed for one answer
+}
+
+// DefaultAgentConfig returns the agent settings: no agent, so prompts are
+// only printed unless one is configured
+func DefaultAgentConfig() AgentConfig {
+	return AgentConfig{
+		APIKeyEnv: "OPENAI_API_KEY",
+		Timeout:   "5m",
+	}
+}
+
+// Enabled reports whether an agent command or URL is configured
+func (c AgentConfig) Enabled() bool {
+	return c.Command != "" || c.URL != ""
+}
+
+// Agent answers a fix prompt, ideally with a unified diff
+type Agent interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// NewAgent creates the agent an AgentConfig describes. Commands run in dir.
+func NewAgent(config AgentConfig, dir string) (Agent, error) {
+	defaults := DefaultAgentConfig()
+	if config.Timeout == "" {
+		config.Timeout = defaults.Timeout
+	}
+	timeout, err := time.ParseDuration(config.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent.timeout: %w", err)
+	}
+
+	switch {
+	case config.Command != "" && config.URL != "":
+		return nil, fmt.Errorf("agent.command and agent.url can't both be set")
+	case config.Command != "":
+		return &commandAgent{command: config.Command, dir: dir, timeout: timeout}, nil
+	case config.URL != "":
+		agent := &httpAgent{url: chatCompletionsURL(config.URL), model: config.Model, client: &http.Client{Timeout: timeout}}
+		if config.APIKeyEnv != "" {
+			agent.apiKey = os.Getenv(config.APIKeyEnv)
+		}
+		return agent, nil
+	}
+	return nil, fmt.Errorf("no agent configured: set agent.command or agent.url")
+}
+
+// commandAgent runs a shell command with the prompt on stdin
+type commandAgent struct {
+	command string
+	dir     string
+	timeout time.Duration
+}
+
+// Complete implements Agent
+func (a *commandAgent) Complete(ctx context.Context, prompt string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", a.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", a.command)
+	}
+	cmd.Dir = a.dir
+	cmd.Stdin = strings.NewReader(prompt)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("agent command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// httpAgent sends the prompt to an OpenAI-compatible chat completions API
+type httpAgent struct {
+	url    string
+	model  string
+	apiKey string
+	client *http.Client
+}
+
+// chatMessage is a message of the chat completions API
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest is a chat completions request
+type chatRequest struct {
+	Model       string        `json:"model,omitempty"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+}
+
+// chatResponse is the part of a chat completions response we use
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// chatCompletionsURL returns the chat completions endpoint under a base URL
+func chatCompletionsURL(base string) string {
+	base = strings.TrimSuffix(base, "/")
+	if strings.HasSuffix(base, "/chat/completions") {
+		return base
+	}
+	return base + "/chat/completions"
+}
+
+// Complete implements Agent
+func (a *httpAgent) Complete(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:    a.model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "genesis-validator (agent)")
+	if a.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("agent request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return "", fmt.Errorf("agent request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("agent request failed: %s: %s", resp.Status, strings.TrimSpace(string(data[:min(len(data), 500)])))
+	}
+
+	var chat chatResponse
+	if err := json.Unmarshal(data, &chat); err != nil {
+		return "", fmt.Errorf("invalid agent response: %w", err)
+	}
+	if len(chat.Choices) == 0 {
+		return "", fmt.Errorf("agent response has no choices")
+	}
+	return chat.Choices[0].Message.Content, nil
+}
+
+// extractDiff returns the unified diff in an agent's answer: the contents of
+// its diff code blocks, or the whole answer when it has none
+func extractDiff(answer string) string {
+	var blocks []string
+	lines := strings.Split(answer, "\n")
+	for i := 0; i < len(lines); i++ {
+		fence := strings.TrimSpace(lines[i])
+		lang := strings.TrimLeft(fence, "`")
+		if len(fence)-len(lang) < 3 || (lang != "diff" && lang != "patch") {
+			continue
+		}
+		fence = fence[:len(fence)-len(lang)]
+
+		var block []string
+		for i++; i < len(lines) && strings.TrimSpace(lines[i]) != fence; i++ {
+			block = append(block, lines[i])
+		}
+		blocks = append(blocks, strings.Join(block, "\n"))
+	}
+
+	if len(blocks) == 0 {
+		return answer
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// AgentAttempt is the outcome of sending one prompt to an agent
+type AgentAttempt struct {
+	Part     int      // 1-based prompt number
+	Before   int      // Findings before the patch
+	After    int      // Findings with the patch applied; 0 when it couldn't be applied
+	Files    []string // Files the patch changes
+	Patch    string   // The diff the agent returned
+	Accepted bool     // The patch reduced the findings and was kept
+	Reason   string   // Why the patch was rejected
+}
+
+// FixWithAgent sends the fix prompts for a result to an agent. Each diff it
+// returns is applied to an in-memory copy of the repository, which is then
+// validated again; the patch is kept only when the number of findings goes
+// down. Kept patches are written to the working tree. It returns one attempt
+// per prompt.
+func (v *Validator) FixWithAgent(ctx context.Context, agent Agent, result *ValidationResult) ([]AgentAttempt, error) {
+	if v.ws.root == "" {
+		return nil, fmt.Errorf("agent fixes need a repository on disk")
+	}
+
+	prompts, err := NewPromptGenerator(v.config).GeneratePrompts(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(prompts) == 0 {
+		return nil, nil
+	}
+
+	original, err := v.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files := original
+	before, err := v.countFindings(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+
+	var attempts []AgentAttempt
+	changed := make(map[string]bool)
+	for i, prompt := range prompts {
+		attempt := AgentAttempt{Part: i + 1, Before: before}
+		v.log.Info("asking agent for a patch", "part", i+1, "parts", len(prompts))
+
+		answer, err := agent.Complete(ctx, prompt+agentInstructions)
+		if ctx.Err() != nil {
+			return attempts, ctx.Err()
+		}
+		if err != nil {
+			attempt.Reason = err.Error()
+			attempts = append(attempts, attempt)
+			continue
+		}
+		attempt.Patch = extractDiff(answer)
+
+		patched, paths, err := applyDiff(files, attempt.Patch)
+		if err != nil {
+			attempt.Reason = "patch does not apply: " + err.Error()
+			attempts = append(attempts, attempt)
+			continue
+		}
+		attempt.Files = paths
+
+		after, err := v.countFindings(ctx, patched)
+		if err != nil {
+			return attempts, err
+		}
+		attempt.After = after
+		if after < before {
+			attempt.Accepted = true
+			files, before = patched, after
+			for _, path := range paths {
+				changed[path] = true
+			}
+		} else {
+			attempt.Reason = fmt.Sprintf("findings did not go down (%d → %d)", before, after)
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, v.writeChanges(original, files, changed)
+}
+
+// applyDiff parses a diff and applies it to files, returning the new files
+// and the sorted paths it changed
+func applyDiff(files map[string][]byte, diff string) (map[string][]byte, []string, error) {
+	patches, err := ParsePatch(diff)
+	if err != nil {
+		return nil, nil, err
+	}
+	patched, paths, err := applyPatches(files, patches)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(paths)
+	return patched, paths, nil
+}
+
+// snapshot reads every discovered file of the repository into memory
+func (v *Validator) snapshot(ctx context.Context) (map[string][]byte, error) {
+	repo, err := v.linkValidator.repoFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte, len(repo.files))
+	for _, file := range repo.files {
+		data, err := v.ws.ReadFile(file)
+		if err != nil {
+			continue // Deleted since discovery, or not a regular file
+		}
+		files[file] = data
+	}
+	return files, nil
+}
+
+// countFindings validates an in-memory copy of the repository and returns
+// its number of findings. External links aren't checked, so counts compare
+// only what the patch can change.
+func (v *Validator) countFindings(ctx context.Context, files map[string][]byte) (int, error) {
+	config := *v.config
+	config.ExternalLinks.Enabled = false
+	result, err := NewValidatorWith(&config, Options{FS: newMemFS(files)}).ValidateContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(result.Findings()) + len(result.Errors), nil
+}
+
+// writeChanges writes files changed from the original snapshot to the
+// working tree, removing deleted ones. Existing files keep their mode.
+func (v *Validator) writeChanges(original, files map[string][]byte, changed map[string]bool) error {
+	paths := make([]string, 0, len(changed))
+	for path := range changed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		abs := v.ws.Abs(filepath.FromSlash(path))
+		data, ok := files[path]
+		if !ok {
+			if _, existed := original[path]; existed {
+				if err := os.Remove(abs); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+			return err
+		}
+		// Keep the mode of existing files, such as the executable bit of scripts
+		mode := os.FileMode(0644)
+		if info, err := os.Stat(abs); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := os.WriteFile(abs, data, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
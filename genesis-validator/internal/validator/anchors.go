@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// headingPattern matches ATX headings (# Heading)
+var headingPattern = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+
+// placeholderPattern matches unreplaced template variables such as {{PROJECT_NAME}}
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Z][A-Z0-9_]*)\s*\}\}`)
+
+// Heading is a markdown heading and the anchor GitHub generates for it
+type Heading struct {
+	Level  int
+	Text   string
+	Anchor string
+	Line   int
+}
+
+// Placeholder is an unreplaced {{VARIABLE}} in markdown prose
+type Placeholder struct {
+	Name   string
+	Line   int
+	Column int // Byte offset of the placeholder within the line
+	Text   string
+}
+
+// extractHeadings returns the headings in markdown content, skipping fenced code.
+// Anchors follow GitHub's rules, including -1, -2 suffixes for duplicates.
+func extractHeadings(r io.Reader) ([]Heading, error) {
+	var headings []Heading
+	counts := make(map[string]int)
+
+	err := scanMarkdownLines(r, func(lineNum int, line string) {
+		match := headingPattern.FindStringSubmatch(line)
+		if match == nil {
+			return
+		}
+
+		text := match[2]
+		anchor := slugify(text)
+		if n := counts[anchor]; n > 0 {
+			counts[anchor]++
+			anchor = fmt.Sprintf("%s-%d", anchor, n)
+		} else {
+			counts[anchor] = 1
+		}
+
+		headings = append(headings, Heading{Level: len(match[1]), Text: text, Anchor: anchor, Line: lineNum})
+	})
+
+	return headings, err
+}
+
+// findPlaceholders returns {{VARIABLE}} occurrences outside code blocks and inline code
+func findPlaceholders(r io.Reader) ([]Placeholder, error) {
+	var placeholders []Placeholder
+
+	err := scanMarkdownLines(r, func(lineNum int, line string) {
+		prose := maskInlineCode(line)
+		for _, loc := range placeholderPattern.FindAllStringSubmatchIndex(prose, -1) {
+			placeholders = append(placeholders, Placeholder{
+				Name:   prose[loc[2]:loc[3]],
+				Line:   lineNum,
+				Column: loc[0],
+				Text:   line[loc[0]:loc[1]],
+			})
+		}
+	})
+
+	return placeholders, err
+}
+
+// inGenesisRoot reports whether a file is part of the genesis documentation,
+// where {{VARIABLES}} are documented rather than left unreplaced
+func inGenesisRoot(genesisRoot, file string) bool {
+	root := filepath.Clean(genesisRoot)
+	return file == root || strings.HasPrefix(file, root+string(filepath.Separator))
+}
+
+// scanMarkdownLines calls fn for every line outside fenced code blocks
+func scanMarkdownLines(r io.Reader, fn func(lineNum int, line string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	inCodeBlock := false
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		if codeFencePattern.MatchString(line) {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		fn(lineNum, line)
+	}
+
+	return scanner.Err()
+}
+
+// codeFencePattern matches fenced code block start/end (``` or ~~~)
+var codeFencePattern = regexp.MustCompile("^\\s*(`{3,}|~{3,})")
+
+// inlineCodePattern matches inline code spans
+var inlineCodePattern = regexp.MustCompile("`[^`]+`")
+
+// maskInlineCode blanks out inline code spans while preserving byte offsets
+func maskInlineCode(line string) string {
+	return inlineCodePattern.ReplaceAllStringFunc(line, func(code string) string {
+		return strings.Repeat(" ", len(code))
+	})
+}
+
+// slugify converts heading text to a GitHub-style anchor
+func slugify(text string) string {
+	// Drop link targets and emphasis markers so [Text](url) anchors as "text"
+	text = markdownLinkPattern.ReplaceAllString(text, "$1")
+	text = strings.NewReplacer("`", "", "*", "", "~~", "").Replace(text)
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// markdownLinkPattern matches markdown links: [text](url)
+var markdownLinkPattern = regexp.MustCompile(`\[([^\]]*)\]\(([^)]+)\)`)
+
+// findAnchor returns the heading whose anchor matches, or nil
+func findAnchor(headings []Heading, anchor string) *Heading {
+	anchor = strings.ToLower(anchor)
+	for i := range headings {
+		if headings[i].Anchor == anchor {
+			return &headings[i]
+		}
+	}
+	return nil
+}
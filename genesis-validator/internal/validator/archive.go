@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// maxArchiveFileSize bounds a single file read from an archive, so a
+// malicious archive can't exhaust memory
+const maxArchiveFileSize = 64 << 20
+
+// OpenArchive reads a .tar.gz, .tgz, .tar or .zip archive into a read-only
+// file system. A single top-level directory holding everything, as in GitHub
+// release archives, becomes the root.
+func OpenArchive(name string) (fs.FS, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var files map[string][]byte
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		files, err = readZip(data)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		var gz *gzip.Reader
+		gz, err = gzip.NewReader(bytes.NewReader(data))
+		if err == nil {
+			files, err = readTar(gz)
+		}
+	case strings.HasSuffix(lower, ".tar"):
+		files, err = readTar(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("unsupported archive %s: want .tar.gz, .tgz, .tar or .zip", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", name, err)
+	}
+
+	return newMemFS(stripTopDir(files)), nil
+}
+
+// readTar reads the regular files of a tar stream
+func readTar(r io.Reader) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := readLimited(tr, header.Name)
+		if err != nil {
+			return nil, err
+		}
+		files[header.Name] = data
+	}
+}
+
+// readZip reads the regular files of a zip archive
+func readZip(data []byte) (map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := readLimited(rc, f.Name)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[f.Name] = content
+	}
+	return files, nil
+}
+
+// readLimited reads an archive member, refusing ones over maxArchiveFileSize
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxArchiveFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxArchiveFileSize {
+		return nil, fmt.Errorf("%s is larger than %d MiB", name, maxArchiveFileSize>>20)
+	}
+	return data, nil
+}
+
+// stripTopDir removes a directory that every file lies beneath
+func stripTopDir(files map[string][]byte) map[string][]byte {
+	top := ""
+	for name := range files {
+		first, _, ok := strings.Cut(path.Clean(strings.TrimPrefix(name, "./")), "/")
+		if !ok || (top != "" && first != top) {
+			return files
+		}
+		top = first
+	}
+	if top == "" {
+		return files
+	}
+
+	stripped := make(map[string][]byte, len(files))
+	for name, data := range files {
+		stripped[strings.TrimPrefix(path.Clean(strings.TrimPrefix(name, "./")), top+"/")] = data
+	}
+	return stripped
+}
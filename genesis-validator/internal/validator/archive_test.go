@@ -0,0 +1,193 @@
+package validator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// archiveFiles is a small genesis tree with one broken link
+var archiveFiles = map[string]string{
+	"README.md":                           "# Repo\n\n[Start](genesis/START-HERE.md)\n[Gone](docs/missing.md)\n",
+	"genesis/START-HERE.md":               "# Start\n\ncp genesis/templates/index-template.md index.md\n",
+	"genesis/CHECKLIST.md":                "# Checklist\n",
+	"genesis/templates/index-template.md": "# Index\n",
+}
+
+func writeTarGz(t *testing.T, name, prefix string) {
+	t.Helper()
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for path, content := range archiveFiles {
+		if err := tw.WriteHeader(&tar.Header{Name: prefix + path, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(tw, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: prefix + "link.md", Linkname: "README.md", Typeflag: tar.TypeSymlink}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, name, prefix string) {
+	t.Helper()
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for path, content := range archiveFiles {
+		w, err := zw.Create(prefix + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	tests := []struct {
+		name  string
+		write func(*testing.T, string, string)
+		top   string
+	}{
+		{"repo.tar.gz", writeTarGz, ""},
+		{"repo-1.0.tgz", writeTarGz, "repo-1.0/"}, // Top-level directory is stripped
+		{"repo.zip", writeZip, ""},
+		{"repo-main.zip", writeZip, "repo-main/"},
+	}
+
+	var paths []string
+	for path := range archiveFiles {
+		paths = append(paths, path)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := filepath.Join(tmpDir, tt.name)
+			tt.write(t, name, tt.top)
+
+			fsys, err := OpenArchive(name)
+			if err != nil {
+				t.Fatalf("OpenArchive() error = %v", err)
+			}
+			if err := fstest.TestFS(fsys, paths...); err != nil {
+				t.Error(err)
+			}
+			if _, err := fsys.Open("link.md"); err == nil {
+				t.Error("symlink was read as a file")
+			}
+		})
+	}
+
+	if _, err := OpenArchive(filepath.Join(tmpDir, "repo.rar")); err == nil {
+		t.Error("OpenArchive(.rar) error = nil, want unsupported archive")
+	}
+}
+
+func TestValidate_Archive(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "release.tar.gz")
+	writeTarGz(t, name, "genesis-v1/")
+	fsys, err := OpenArchive(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The working directory must not matter
+	chdir(t, t.TempDir())
+	config := DefaultConfig()
+	config.EntryPoints = nil
+	result, err := NewValidatorWith(config, Options{FS: fsys}).ValidateContext(context.Background())
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var links []string
+	for _, link := range result.BrokenLinks {
+		links = append(links, link.SourceFile+" -> "+link.LinkURL)
+	}
+	if want := []string{"README.md -> docs/missing.md"}; !reflect.DeepEqual(links, want) {
+		t.Errorf("broken links = %v, want %v", links, want)
+	}
+	if len(result.OrphanedFiles) != 0 || len(result.MissingFiles) != 0 {
+		t.Errorf("orphaned = %v, missing = %v, want none", result.OrphanedFiles, result.MissingFiles)
+	}
+}
+
+func TestGitRepo_TreeFS(t *testing.T) {
+	files := map[string]string{}
+	for path, content := range archiveFiles {
+		files[path] = content
+	}
+	dir := setupGitRepo(t, files)
+
+	// Fix the link in a second commit and break another in the working tree
+	writeTestFile(t, dir, "README.md", "# Repo\n\n[Start](genesis/START-HERE.md)\n")
+	runGit(t, dir, "commit", "-q", "-am", "fix link")
+	writeTestFile(t, dir, "genesis/CHECKLIST.md", "# Checklist\n\n[Nope](nope.md)\n")
+
+	repo := NewGitRepo(dir)
+	brokenLinks := func(rev string) []string {
+		t.Helper()
+		fsys, err := repo.TreeFS(rev)
+		if err != nil {
+			t.Fatalf("TreeFS(%s) error = %v", rev, err)
+		}
+		config := DefaultConfig()
+		config.EntryPoints = nil
+		result, err := NewValidatorWith(config, Options{FS: fsys}).ValidateContext(context.Background())
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		var links []string
+		for _, link := range result.BrokenLinks {
+			links = append(links, link.SourceFile+" -> "+link.LinkURL)
+		}
+		return links
+	}
+
+	if got, want := brokenLinks("HEAD~1"), []string{"README.md -> docs/missing.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("HEAD~1 broken links = %v, want %v", got, want)
+	}
+	if got := brokenLinks("HEAD"); len(got) != 0 {
+		t.Errorf("HEAD broken links = %v, want none (working tree changes are ignored)", got)
+	}
+
+	fsys, err := repo.TreeFS("HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(fsys, "README.md", "genesis/templates/index-template.md"); err != nil {
+		t.Error(err)
+	}
+	if _, err := repo.TreeFS("no-such-branch"); err == nil {
+		t.Error("TreeFS(no-such-branch) error = nil, want unknown revision")
+	}
+}
@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// baselineVersion is the current baseline file format version
+const baselineVersion = 1
+
+// Baseline records accepted findings so only new findings fail validation
+type Baseline struct {
+	Version  int             `json:"version"`
+	Findings []BaselineEntry `json:"findings"`
+}
+
+// BaselineEntry is one accepted finding. Rule, File and Message are kept for
+// readability when reviewing baseline diffs; only Fingerprint is matched.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	File        string `json:"file"`
+	Message     string `json:"message"`
+}
+
+// NewBaseline records every finding in the result
+func NewBaseline(result *ValidationResult) *Baseline {
+	fingerprints := newFingerprinter(result)
+	baseline := &Baseline{Version: baselineVersion, Findings: []BaselineEntry{}}
+
+	for _, inc := range result.Inconsistencies {
+		baseline.Findings = append(baseline.Findings, BaselineEntry{
+			Fingerprint: fingerprints.of(inc),
+			Rule:        inc.Type,
+			File:        inc.File,
+			Message:     inc.Description,
+		})
+	}
+
+	sort.SliceStable(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+
+	return baseline
+}
+
+// LoadBaseline reads a baseline file
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s", baseline.Version, path)
+	}
+
+	return &baseline, nil
+}
+
+// Save writes the baseline as indented JSON
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Apply removes findings recorded in the baseline from the result and returns
+// how many were removed. Each baseline entry accepts one occurrence, so a
+// second copy of an accepted finding is still reported.
+func (b *Baseline) Apply(result *ValidationResult) int {
+	remaining := make(map[string]int)
+	for _, entry := range b.Findings {
+		remaining[entry.Fingerprint]++
+	}
+
+	fingerprints := newFingerprinter(result)
+	removed := result.removeFindings(func(inc Inconsistency) bool {
+		fp := fingerprints.of(inc)
+		if remaining[fp] == 0 {
+			return false
+		}
+		remaining[fp]--
+		return true
+	})
+	result.Baselined += removed
+
+	return removed
+}
+
+// fingerprinter computes stable finding fingerprints, caching file contents
+// used as surrounding context
+type fingerprinter struct {
+	read  func(string) ([]byte, error) // Reads a finding's file
+	lines map[string][]string
+}
+
+// newFingerprinter reads finding files the way the result's validation did,
+// or from its repository root on disk
+func newFingerprinter(result *ValidationResult) *fingerprinter {
+	return &fingerprinter{read: result.source, lines: make(map[string][]string)}
+}
+
+// of returns a fingerprint built from the rule ID, file, normalized message
+// and the normalized source line, so that line shifts don't change it
+func (f *fingerprinter) of(inc Inconsistency) string {
+	h := sha256.New()
+	for _, part := range []string{inc.Type, inc.File, normalizeMessage(inc.Description), f.context(inc)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// context returns the normalized content of the finding's source line
+func (f *fingerprinter) context(inc Inconsistency) string {
+	if inc.Line <= 0 {
+		return ""
+	}
+
+	lines, ok := f.lines[inc.File]
+	if !ok {
+		if data, err := f.read(inc.File); err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		f.lines[inc.File] = lines
+	}
+
+	if inc.Line > len(lines) {
+		return ""
+	}
+	return strings.Join(strings.Fields(lines[inc.Line-1]), " ")
+}
+
+// normalizeMessage collapses whitespace and case differences in a message
+func normalizeMessage(msg string) string {
+	return strings.ToLower(strings.Join(strings.Fields(msg), " "))
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBaseline_SurvivesLineShifts(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	writeTestFile(t, tmpDir, "docs/guide.md", "# Guide\n\n[Old](old.md)\n")
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(result.BrokenLinks) != 1 {
+		t.Fatalf("expected 1 broken link, got %d", len(result.BrokenLinks))
+	}
+
+	baselinePath := filepath.Join(tmpDir, "baseline.json")
+	if err := NewBaseline(result).Save(baselinePath); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	baseline, err := LoadBaseline(baselinePath)
+	if err != nil {
+		t.Fatalf("LoadBaseline() error = %v", err)
+	}
+
+	// Shift the existing finding down and add a new one
+	writeTestFile(t, tmpDir, "docs/guide.md", "# Guide\n\nIntro.\n\nMore intro.\n\n[Old](old.md)\n[New](new.md)\n")
+
+	result, err = NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if removed := baseline.Apply(result); removed != 1 {
+		t.Errorf("Apply() removed %d findings, want 1", removed)
+	}
+
+	if len(result.BrokenLinks) != 1 || result.BrokenLinks[0].LinkURL != "new.md" {
+		t.Errorf("expected only the new broken link, got %+v", result.BrokenLinks)
+	}
+	if len(result.Inconsistencies) != 1 || result.Baselined != 1 {
+		t.Errorf("Inconsistencies = %d, Baselined = %d", len(result.Inconsistencies), result.Baselined)
+	}
+	if result.IsValid() {
+		t.Error("new finding should still fail validation")
+	}
+}
+
+func TestBaseline_AcceptsEachOccurrenceOnce(t *testing.T) {
+	result := &ValidationResult{
+		MissingFiles: []string{"templates/a-template.js"},
+	}
+	result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
+		Type:        "missing_file",
+		File:        "templates/a-template.js",
+		Description: "Referenced in documentation but file does not exist",
+	})
+	baseline := NewBaseline(result)
+
+	// The same finding twice: the baseline accepts only one of them
+	result = &ValidationResult{MissingFiles: []string{"templates/a-template.js"}}
+	for i := 0; i < 2; i++ {
+		result.Inconsistencies = append(result.Inconsistencies, baseline.findingFor(0))
+	}
+
+	if removed := baseline.Apply(result); removed != 1 {
+		t.Errorf("Apply() removed %d findings, want 1", removed)
+	}
+	if len(result.MissingFiles) != 0 {
+		t.Errorf("MissingFiles = %v, want empty", result.MissingFiles)
+	}
+	if len(result.Inconsistencies) != 1 {
+		t.Errorf("Inconsistencies = %d, want 1", len(result.Inconsistencies))
+	}
+}
+
+func TestValidationResult_SortIsDeterministic(t *testing.T) {
+	result := &ValidationResult{
+		MissingFiles: []string{"templates/z.js", "templates/a.js"},
+		BrokenLinks: []BrokenLink{
+			{SourceFile: "b.md", Line: 1},
+			{SourceFile: "a.md", Line: 9},
+			{SourceFile: "a.md", Line: 2},
+		},
+	}
+	result.Sort()
+
+	if result.MissingFiles[0] != "templates/a.js" {
+		t.Errorf("MissingFiles not sorted: %v", result.MissingFiles)
+	}
+	if result.BrokenLinks[0].Line != 2 || result.BrokenLinks[1].Line != 9 || result.BrokenLinks[2].SourceFile != "b.md" {
+		t.Errorf("BrokenLinks not sorted: %+v", result.BrokenLinks)
+	}
+}
+
+// findingFor rebuilds the inconsistency recorded by a baseline entry
+func (b *Baseline) findingFor(i int) Inconsistency {
+	entry := b.Findings[i]
+	return Inconsistency{Type: entry.Rule, File: entry.File, Description: entry.Message}
+}
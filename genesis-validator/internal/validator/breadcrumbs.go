@@ -0,0 +1,241 @@
+package validator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// breadcrumbPattern matches the breadcrumb that opens a split-out sub-document:
+//
+//	> Part of [Customization Guide](../03-CUSTOMIZATION-GUIDE.md)
+//	> **Parent:** [`TROUBLESHOOTING.md`](../TROUBLESHOOTING.md)
+var breadcrumbPattern = regexp.MustCompile(`^>\s*(?:Part of|\*\*Parent:\*\*)\s*\[`)
+
+// breadcrumbSearchLines is how far into a file the breadcrumb is looked for
+const breadcrumbSearchLines = 15
+
+// Breadcrumb is the parent link of a split-out sub-document
+type Breadcrumb struct {
+	File   string // The sub-document
+	Parent string // The parent index it belongs to, resolved relative to the repository root
+	Line   int
+}
+
+// findBreadcrumb returns the breadcrumb near the top of a markdown file. When
+// the breadcrumb is a chain (A → B), the last link is the immediate parent.
+func findBreadcrumb(read func(string) ([]byte, error), file string) (*Breadcrumb, error) {
+	content, err := read(file)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := extractLinksFrom(strings.NewReader(string(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.SplitN(string(content), "\n", breadcrumbSearchLines+1)
+	for i, line := range lines {
+		if i == breadcrumbSearchLines || !breadcrumbPattern.MatchString(line) {
+			continue
+		}
+
+		var parent string
+		for _, link := range links {
+			if link.line == i+1 {
+				parent = stripAnchor(link.url)
+			}
+		}
+		if parent == "" || strings.Contains(parent, "://") {
+			continue
+		}
+
+		return &Breadcrumb{
+			File:   file,
+			Parent: filepath.Join(filepath.Dir(file), filepath.FromSlash(parent)),
+			Line:   i + 1,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// breadcrumbFinding is a breadcrumb finding and the edits that resolve it
+type breadcrumbFinding struct {
+	Inconsistency
+	fixes []Fix
+}
+
+// validateBreadcrumbs checks that every breadcrumb's parent links back to the
+// sub-document, and that every file in a split folder has a breadcrumb. A
+// split folder is a configured SplitDirs entry or any folder in which at
+// least one file has a breadcrumb.
+func (v *Validator) validateBreadcrumbs(mdFiles []string) ([]Inconsistency, error) {
+	found, err := v.checkBreadcrumbs(mdFiles)
+	if err != nil {
+		return nil, err
+	}
+	findings := make([]Inconsistency, len(found))
+	for i, f := range found {
+		findings[i] = f.Inconsistency
+	}
+	return findings, nil
+}
+
+// checkBreadcrumbs returns the breadcrumb findings with the fixes that insert
+// missing breadcrumbs and parent links
+func (v *Validator) checkBreadcrumbs(mdFiles []string) ([]breadcrumbFinding, error) {
+	var findings []breadcrumbFinding
+
+	crumbs := make(map[string]*Breadcrumb)
+	splitDirs := make(map[string]bool)
+	for _, dir := range v.config.SplitDirs {
+		splitDirs[filepath.Clean(dir)] = true
+	}
+
+	for _, file := range mdFiles {
+		crumb, err := findBreadcrumb(v.ws.ReadFile, file)
+		if err != nil {
+			return nil, err
+		}
+		if crumb != nil {
+			crumbs[file] = crumb
+			splitDirs[filepath.Dir(file)] = true
+		}
+	}
+
+	parentLinks := make(map[string][]linkInfo)
+	linksOf := func(parent string) []linkInfo {
+		if links, ok := parentLinks[parent]; ok {
+			return links
+		}
+		file, err := v.ws.Open(parent)
+		if err != nil {
+			parentLinks[parent] = nil
+			return nil
+		}
+		defer func() { _ = file.Close() }()
+		links, _ := extractLinksFrom(file)
+		parentLinks[parent] = links
+		return links
+	}
+
+	// Siblings without a breadcrumb are checked against the folder's usual parent
+	dirParents := make(map[string]map[string]int)
+	for _, crumb := range crumbs {
+		dir := filepath.Dir(crumb.File)
+		if dirParents[dir] == nil {
+			dirParents[dir] = make(map[string]int)
+		}
+		dirParents[dir][crumb.Parent]++
+	}
+
+	files := append([]string(nil), mdFiles...)
+	sort.Strings(files)
+
+	for _, file := range files {
+		display := displayPath(file)
+		crumb := crumbs[file]
+
+		if crumb != nil {
+			if !v.ws.Exists(crumb.Parent) {
+				continue // Reported as a broken link
+			}
+			if !linksTo(crumb.Parent, linksOf(crumb.Parent), file) {
+				findings = append(findings, breadcrumbFinding{
+					Inconsistency: Inconsistency{
+						Type:        "unlinked_child",
+						File:        displayPath(crumb.Parent),
+						Description: fmt.Sprintf("Parent index does not link to sub-document %s", display),
+						Location:    fmt.Sprintf("%s:%d", display, crumb.Line),
+					},
+					fixes: v.childLinkFixes(crumb.Parent, linksOf(crumb.Parent), file),
+				})
+			}
+			continue
+		}
+
+		if !splitDirs[filepath.Dir(file)] {
+			continue
+		}
+
+		description := "File in a split folder has no \"> Part of [Parent](../PARENT.md)\" breadcrumb"
+		var fixes []Fix
+		if parent := mostCommon(dirParents[filepath.Dir(file)]); parent != "" {
+			fixes = v.breadcrumbFixes(file, siblingBreadcrumb(crumbs, file, parent))
+			if !linksTo(parent, linksOf(parent), file) {
+				description += fmt.Sprintf(" and is not linked from %s", displayPath(parent))
+				fixes = append(fixes, v.childLinkFixes(parent, linksOf(parent), file)...)
+			}
+		}
+		findings = append(findings, breadcrumbFinding{
+			Inconsistency: Inconsistency{
+				Type:        "missing_breadcrumb",
+				File:        display,
+				Description: description,
+				Location:    display,
+			},
+			fixes: fixes,
+		})
+	}
+
+	return findings, nil
+}
+
+// siblingBreadcrumb returns the breadcrumb of the first file beside file
+// whose parent is parent
+func siblingBreadcrumb(crumbs map[string]*Breadcrumb, file, parent string) *Breadcrumb {
+	var sibling *Breadcrumb
+	for other, crumb := range crumbs {
+		if filepath.Dir(other) == filepath.Dir(file) && crumb.Parent == parent && (sibling == nil || other < sibling.File) {
+			sibling = crumb
+		}
+	}
+	return sibling
+}
+
+// breadcrumbFixes inserts a copy of a sibling's breadcrumb line below the
+// title of file. Siblings share a folder, so the parent link is unchanged.
+func (v *Validator) breadcrumbFixes(file string, sibling *Breadcrumb) []Fix {
+	if sibling == nil {
+		return nil
+	}
+	siblingContent, err := v.ws.ReadFile(sibling.File)
+	if err != nil {
+		return nil
+	}
+	crumbLine := strings.Split(string(siblingContent), "\n")[sibling.Line-1]
+	content, err := v.ws.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	return []Fix{breadcrumbFix(file, content, strings.TrimRight(crumbLine, "\r"))}
+}
+
+// childLinkFixes adds a link to child to a parent index
+func (v *Validator) childLinkFixes(parent string, links []linkInfo, child string) []Fix {
+	content, err := v.ws.ReadFile(parent)
+	if err != nil {
+		return nil
+	}
+	childContent, err := v.ws.ReadFile(child)
+	if err != nil {
+		return nil
+	}
+	return []Fix{childLinkFix(parent, content, links, child, documentTitle(child, childContent))}
+}
+
+// mostCommon returns the key with the highest count, preferring the
+// lexically smallest on ties
+func mostCommon(counts map[string]int) string {
+	best := ""
+	for key, n := range counts {
+		if n > counts[best] || (n == counts[best] && key < best) {
+			best = key
+		}
+	}
+	return best
+}
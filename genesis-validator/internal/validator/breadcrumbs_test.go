@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFindBreadcrumb(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "guide/part.md", "# Part\n\n> Part of [Guide](../GUIDE.md)\n")
+	writeTestFile(t, tmpDir, "guide/parent.md", "# Part\n\n> **Parent:** [`GUIDE.md`](../GUIDE.md#top)\n")
+	writeTestFile(t, tmpDir, "guide/chain.md", "# Chain\n\n> Part of [Guide](../GUIDE.md) → [Examples](./examples.md)\n")
+	writeTestFile(t, tmpDir, "guide/none.md", "# None\n\n> Just a quote with [a link](../GUIDE.md)\n")
+	chdir(t, tmpDir)
+
+	tests := []struct {
+		file       string
+		wantParent string
+	}{
+		{"guide/part.md", "GUIDE.md"},
+		{"guide/parent.md", "GUIDE.md"},
+		{"guide/chain.md", "guide/examples.md"},
+		{"guide/none.md", ""},
+	}
+
+	for _, tt := range tests {
+		crumb, err := findBreadcrumb(os.ReadFile, tt.file)
+		if err != nil {
+			t.Fatalf("findBreadcrumb(%s) error = %v", tt.file, err)
+		}
+		got := ""
+		if crumb != nil {
+			got = displayPath(crumb.Parent)
+		}
+		if got != tt.wantParent {
+			t.Errorf("findBreadcrumb(%s) parent = %q, want %q", tt.file, got, tt.wantParent)
+		}
+	}
+}
+
+func TestValidate_Breadcrumbs(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	config.SplitDirs = []string{"docs/empty-split"}
+	writeTestFile(t, tmpDir, "docs/GUIDE.md", "# Guide\n\n- [Setup](guide/setup.md)\n- [Extras](guide/extras.md)\n")
+	writeTestFile(t, tmpDir, "docs/guide/setup.md", "# Setup\n\n> Part of [Guide](../GUIDE.md)\n")
+	writeTestFile(t, tmpDir, "docs/guide/deploy.md", "# Deploy\n\n> Part of [Guide](../GUIDE.md)\n")
+	writeTestFile(t, tmpDir, "docs/guide/extras.md", "# Extras\n\nNo breadcrumb, but linked from the guide.\n")
+	writeTestFile(t, tmpDir, "docs/guide/stray.md", "# Stray\n\nNo breadcrumb and not linked.\n")
+	writeTestFile(t, tmpDir, "docs/empty-split/lonely.md", "# Lonely\n")
+	writeTestFile(t, tmpDir, "docs/plain/notes.md", "# Notes\n")
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var got []string
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "unlinked_child" || inc.Type == "missing_breadcrumb" {
+			got = append(got, fmt.Sprintf("%s %s: %s", inc.Type, inc.File, inc.Description))
+		}
+	}
+
+	want := []string{
+		"unlinked_child docs/GUIDE.md: Parent index does not link to sub-document docs/guide/deploy.md",
+		`missing_breadcrumb docs/empty-split/lonely.md: File in a split folder has no "> Part of [Parent](../PARENT.md)" breadcrumb`,
+		`missing_breadcrumb docs/guide/extras.md: File in a split folder has no "> Part of [Parent](../PARENT.md)" breadcrumb`,
+		`missing_breadcrumb docs/guide/stray.md: File in a split folder has no "> Part of [Parent](../PARENT.md)" breadcrumb and is not linked from docs/GUIDE.md`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("breadcrumb findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestChildLinkFix(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		line    int
+	}{
+		{"table", "# Guide\n\n| Doc | About |\n|-----|-------|\n| [Setup](./guide/setup.md) | First |\n\nEnd\n", "| [Deploy](./guide/deploy.md) | |", 6},
+		{"numbered", "# Guide\n\n1. [Setup](guide/setup.md)\n2.  [Run](guide/run.md)\n", "3.  [Deploy](guide/deploy.md)", 5},
+		{"appended", "# Guide\n\nSee [Setup](guide/setup.md).\n", "\n- [Deploy](guide/deploy.md)", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links, err := extractLinksFrom(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			fix := childLinkFix("docs/GUIDE.md", []byte(tt.content), links, "docs/guide/deploy.md", "Deploy")
+			if fix.New != tt.want || fix.Line != tt.line || fix.Old != "" {
+				t.Errorf("childLinkFix() = line %d %q, want line %d %q", fix.Line, fix.New, tt.line, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreadcrumbFix(t *testing.T) {
+	crumb := "> Part of [Guide](../GUIDE.md)"
+	tests := []struct {
+		content string
+		want    string
+		line    int
+	}{
+		{"# Extras\n\nMore.\n", crumb + "\n", 3},
+		{"# Extras\nMore.\n", "\n" + crumb + "\n", 2},
+		{"# Extras\n", "\n" + crumb, 2},
+		{"\nNo title.\n", crumb + "\n", 2},
+		{"", crumb, 1},
+	}
+
+	for _, tt := range tests {
+		fix := breadcrumbFix("docs/guide/extras.md", []byte(tt.content), crumb)
+		if fix.New != tt.want || fix.Line != tt.line {
+			t.Errorf("breadcrumbFix(%q) = line %d %q, want line %d %q", tt.content, fix.Line, fix.New, tt.line, tt.want)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Budget limits the size of markdown files matching a glob. Zero limits are
+// not enforced.
+type Budget struct {
+	Glob      string `json:"glob"`                // Slash-separated path pattern, e.g. genesis/steps/*.md; a trailing /** matches everything beneath
+	SplitOnly bool   `json:"splitOnly,omitempty"` // Only files in split folders: splitDirs and folders with a "Part of" breadcrumb
+	MaxLines  int    `json:"maxLines,omitempty"`
+	MaxWords  int    `json:"maxWords,omitempty"`
+	MaxBytes  int    `json:"maxBytes,omitempty"`
+}
+
+// DefaultBudgets returns the size budgets for a genesis root: step files stay
+// within the 150 lines START-HERE promises and split-out sub-guides within 200
+func DefaultBudgets(genesisRoot string) []Budget {
+	root := cleanSlash(genesisRoot)
+	return []Budget{
+		{Glob: root + "/steps/*.md", MaxLines: 150},
+		{Glob: root + "/**", SplitOnly: true, MaxLines: 200},
+	}
+}
+
+// budgetFor returns the first configured budget that applies to file
+func (v *Validator) budgetFor(file string) (Budget, bool) {
+	for _, budget := range v.config.Budgets {
+		if !matchBudgetGlob(budget.Glob, file) {
+			continue
+		}
+		if budget.SplitOnly && !v.inSplitFolder(file) {
+			continue
+		}
+		return budget, true
+	}
+	return Budget{}, false
+}
+
+// matchBudgetGlob matches a budget glob against a repo-relative file. A glob
+// ending in /** matches every file beneath the directory it names.
+func matchBudgetGlob(glob, file string) bool {
+	if dir, ok := strings.CutSuffix(glob, "/**"); ok {
+		return strings.Count(file, "/") > strings.Count(dir, "/") && matchPathPattern(dir, file, true)
+	}
+	matched, _ := path.Match(glob, file)
+	return matched
+}
+
+// inSplitFolder reports whether file sits in a split folder: a configured
+// SplitDirs entry, or a folder in which a markdown file has a breadcrumb
+func (v *Validator) inSplitFolder(file string) bool {
+	dir := path.Dir(file)
+	for _, split := range v.config.SplitDirs {
+		if cleanSlash(v.ws.Anchor(split)) == dir {
+			return true
+		}
+	}
+
+	entries, err := v.ws.ReadDir(filepath.FromSlash(dir))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		if crumb, err := findBreadcrumb(v.ws.ReadFile, filepath.Join(filepath.FromSlash(dir), entry.Name())); err == nil && crumb != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// budgetSection is an H2 section and its size, used to suggest split points
+type budgetSection struct {
+	heading string
+	line    int
+	size    int
+}
+
+// pinned reports whether a section must stay in a step file: its Entry
+// Conditions and Exit Criteria
+func (s budgetSection) pinned() bool {
+	heading := strings.ToLower(s.heading)
+	return strings.Contains(heading, "entry conditions") || strings.Contains(heading, "exit criteria")
+}
+
+// checkBudget reports a markdown file that exceeds its size budget, with
+// split points suggested from its H2 structure. It returns nil when the file
+// has no budget or is within it.
+func (v *Validator) checkBudget(file string) (*Inconsistency, error) {
+	display := displayPath(file)
+	budget, ok := v.budgetFor(display)
+	if !ok {
+		return nil, nil
+	}
+
+	content, err := v.ws.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := splitLines(content)
+	measures := []struct {
+		unit    string
+		limit   int
+		measure func([]string) int
+	}{
+		{"lines", budget.MaxLines, func(l []string) int { return len(l) }},
+		{"words", budget.MaxWords, func(l []string) int { return len(strings.Fields(strings.Join(l, "\n"))) }},
+		{"bytes", budget.MaxBytes, func(l []string) int { return len(strings.Join(l, "\n")) + 1 }},
+	}
+
+	for _, m := range measures {
+		if m.limit <= 0 {
+			continue
+		}
+		total := m.measure(lines)
+		if total <= m.limit {
+			continue
+		}
+
+		description := fmt.Sprintf("%d %s exceeds the budget of %d for %s", total, m.unit, m.limit, budget.Glob)
+		sections := h2Sections(content, lines, m.measure)
+		if v.isStepFile(display) {
+			description += "; " + suggestStepMove(sections, total-m.limit, m.limit)
+		} else {
+			description += "; " + suggestSplits(sections, m.limit)
+		}
+
+		return &Inconsistency{
+			Type:        "over_budget",
+			File:        display,
+			Description: description,
+			Location:    display,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// h2Sections splits a file at its H2 headings. Content before the first H2
+// is returned as a section with an empty heading.
+func h2Sections(content []byte, lines []string, measure func([]string) int) []budgetSection {
+	headings, _ := extractHeadings(bytes.NewReader(content))
+
+	starts := []budgetSection{{line: 1}}
+	for _, h := range headings {
+		if h.Level == 2 {
+			starts = append(starts, budgetSection{heading: h.Text, line: h.Line})
+		}
+	}
+
+	var sections []budgetSection
+	for i, s := range starts {
+		end := len(lines) + 1
+		if i+1 < len(starts) {
+			end = starts[i+1].line
+		}
+		if end <= s.line {
+			continue // File starts with an H2
+		}
+		s.size = measure(lines[s.line-1 : end-1])
+		sections = append(sections, s)
+	}
+	return sections
+}
+
+// suggestSplits groups consecutive H2 sections into parts that fit the limit
+// and describes where each new part should start
+func suggestSplits(sections []budgetSection, limit int) string {
+	if len(sections) <= 1 {
+		return "no H2 headings to split on"
+	}
+
+	var splits, oversized []string
+	size := 0
+	for i, s := range sections {
+		if i > 0 && size > 0 && size+s.size > limit {
+			splits = append(splits, fmt.Sprintf("line %d %q", s.line, "## "+s.heading))
+			size = 0
+		}
+		size += s.size
+		if s.size > limit && s.heading != "" {
+			oversized = append(oversized, fmt.Sprintf("%q", "## "+s.heading))
+		}
+	}
+
+	suggestion := "no split between H2 sections fits the budget"
+	if len(splits) > 0 {
+		suggestion = "split before " + strings.Join(splits, ", ")
+	}
+	if len(oversized) > 0 {
+		suggestion += "; still over budget on its own: " + strings.Join(oversized, ", ")
+	}
+	return suggestion
+}
+
+// isStepFile reports whether a repo-relative file is a step file, which must
+// keep its Entry Conditions and Exit Criteria sections
+func (v *Validator) isStepFile(file string) bool {
+	return path.Dir(file) == cleanSlash(v.ws.Anchor(v.config.StepsDir)) && stepFilePattern.MatchString(path.Base(file))
+}
+
+// suggestStepMove suggests the shortest run of sections between a step's
+// Entry Conditions and Exit Criteria that, moved to a sub-guide, brings the
+// step within budget. Splitting at an H2 would move Exit Criteria out of the
+// step file.
+func suggestStepMove(sections []budgetSection, excess, limit int) string {
+	first, last := -1, -1
+	for i, s := range sections {
+		if s.pinned() {
+			if first == -1 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first == -1 || last == first {
+		return suggestSplits(sections, limit)
+	}
+
+	bestStart, bestEnd := -1, -1
+	for start := first + 1; start < last; start++ {
+		size := 0
+		for end := start; end < last && !sections[end].pinned(); end++ {
+			size += sections[end].size
+			if size >= excess && size <= limit {
+				if bestStart == -1 || end-start < bestEnd-bestStart {
+					bestStart, bestEnd = start, end
+				}
+				break
+			}
+		}
+	}
+	if bestStart == -1 {
+		return "no sections between Entry Conditions and Exit Criteria can be moved out to fit the budget"
+	}
+
+	from, to := sections[bestStart], sections[bestEnd]
+	endLine := sections[bestEnd+1].line - 1
+	if bestStart == bestEnd {
+		return fmt.Sprintf("move %q (lines %d-%d) to a sub-guide, keeping Entry Conditions and Exit Criteria here", "## "+from.heading, from.line, endLine)
+	}
+	return fmt.Sprintf("move %q through %q (lines %d-%d) to a sub-guide, keeping Entry Conditions and Exit Criteria here",
+		"## "+from.heading, "## "+to.heading, from.line, endLine)
+}
+
+// splitLines returns the lines of content without a trailing empty line
+func splitLines(content []byte) []string {
+	text := strings.TrimSuffix(string(content), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
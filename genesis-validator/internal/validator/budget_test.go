@@ -0,0 +1,159 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// sectionedDoc builds a markdown file with an intro and H2 sections of n lines each
+func sectionedDoc(intro int, sections ...int) string {
+	var lines []string
+	lines = append(lines, "# Title")
+	for i := 1; i < intro; i++ {
+		lines = append(lines, "intro")
+	}
+	for s, n := range sections {
+		lines = append(lines, fmt.Sprintf("## Part %d", s+1))
+		for i := 1; i < n; i++ {
+			lines = append(lines, "body text here")
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestCheckBudget(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "genesis/steps/01-small.md", sectionedDoc(5, 10, 10))
+	writeTestFile(t, tmpDir, "genesis/steps/02-large.md", sectionedDoc(10, 40, 40, 40)) // 130 lines
+	writeTestFile(t, tmpDir, "genesis/guide/huge.md", sectionedDoc(5, 100))
+	writeTestFile(t, tmpDir, "README.md", sectionedDoc(500))
+	chdir(t, tmpDir)
+
+	v := NewValidator(&Config{Budgets: []Budget{
+		{Glob: "genesis/steps/*.md", MaxLines: 100},
+		{Glob: "genesis/*/*.md", MaxLines: 50, MaxWords: 10000},
+	}})
+
+	tests := []struct {
+		file string
+		want string // Expected description, "" for no finding
+	}{
+		{"genesis/steps/01-small.md", ""},
+		{"genesis/steps/02-large.md", `130 lines exceeds the budget of 100 for genesis/steps/*.md; split before line 91 "## Part 3"`},
+		{"genesis/guide/huge.md", `105 lines exceeds the budget of 50 for genesis/*/*.md; split before line 6 "## Part 1"; still over budget on its own: "## Part 1"`},
+		{"README.md", ""},
+	}
+
+	for _, tt := range tests {
+		inc, err := v.checkBudget(tt.file)
+		if err != nil {
+			t.Fatalf("checkBudget(%s) error = %v", tt.file, err)
+		}
+		got := ""
+		if inc != nil {
+			got = inc.Description
+		}
+		if got != tt.want {
+			t.Errorf("checkBudget(%s) = %q, want %q", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestCheckBudget_Words(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "docs/guide.md", "# Guide\n\none two three four five six\n")
+	chdir(t, tmpDir)
+
+	v := NewValidator(&Config{Budgets: []Budget{{Glob: "docs/*.md", MaxWords: 5}}})
+	inc, err := v.checkBudget("docs/guide.md")
+	if err != nil {
+		t.Fatalf("checkBudget() error = %v", err)
+	}
+	if inc == nil || !strings.HasPrefix(inc.Description, "8 words exceeds the budget of 5") {
+		t.Errorf("checkBudget() = %+v, want an 8-word finding", inc)
+	}
+}
+
+func TestValidate_Budgets(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	writeTestFile(t, tmpDir, "genesis/steps/01-long.md", sectionedDoc(100, 100))
+	config.Budgets = DefaultBudgets("genesis")
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var found bool
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "over_budget" && inc.File == "genesis/steps/01-long.md" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an over_budget finding, got %+v", result.Inconsistencies)
+	}
+}
+
+func TestCheckBudget_StepKeepsRequiredSections(t *testing.T) {
+	tmpDir := t.TempDir()
+	var lines []string
+	add := func(heading string, n int) {
+		lines = append(lines, heading)
+		for i := 1; i < n; i++ {
+			lines = append(lines, "body text here")
+		}
+	}
+	add("# Step 2", 8)
+	add("## Entry Conditions", 8)
+	add("## Background", 30) // Lines 17-46
+	add("## Process", 60)
+	add("## ⛔ Exit Criteria (ALL MUST PASS)", 40)
+	add("## 🚫 DO NOT PROCEED if:", 10) // 156 lines in all
+	writeTestFile(t, tmpDir, "genesis/steps/02-research.md", strings.Join(lines, "\n")+"\n")
+	chdir(t, tmpDir)
+
+	config := DefaultConfig()
+	v := NewValidator(config)
+	inc, err := v.checkBudget("genesis/steps/02-research.md")
+	if err != nil {
+		t.Fatalf("checkBudget() error = %v", err)
+	}
+	want := `156 lines exceeds the budget of 150 for genesis/steps/*.md; move "## Background" (lines 17-46) to a sub-guide, keeping Entry Conditions and Exit Criteria here`
+	if inc == nil || inc.Description != want {
+		t.Errorf("checkBudget() = %+v, want %q", inc, want)
+	}
+}
+
+func TestCheckBudget_SplitFoldersOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "genesis/GUIDE.md", "# Guide\n")
+	writeTestFile(t, tmpDir, "genesis/guide/part.md", "# Part\n\n> Part of [Guide](../GUIDE.md)\n"+sectionedDoc(5, 100, 100))
+	writeTestFile(t, tmpDir, "genesis/guide/other.md", sectionedDoc(5, 100, 100))
+	writeTestFile(t, tmpDir, "genesis/nested/deep/split.md", sectionedDoc(5, 100, 100))
+	writeTestFile(t, tmpDir, "genesis/docs/notes.md", sectionedDoc(5, 100, 100))
+	writeTestFile(t, tmpDir, "genesis/validation/README.md", sectionedDoc(5, 100, 100))
+	chdir(t, tmpDir)
+
+	config := DefaultConfig()
+	config.SplitDirs = []string{"genesis/nested/deep"}
+	v := NewValidator(config)
+
+	for file, want := range map[string]bool{
+		"genesis/guide/part.md":        true, // Has the breadcrumb
+		"genesis/guide/other.md":       true, // Sibling in a split folder
+		"genesis/nested/deep/split.md": true, // Configured split folder
+		"genesis/docs/notes.md":        false,
+		"genesis/validation/README.md": false,
+	} {
+		inc, err := v.checkBudget(file)
+		if err != nil {
+			t.Fatalf("checkBudget(%s) error = %v", file, err)
+		}
+		if got := inc != nil; got != want {
+			t.Errorf("checkBudget(%s) reported = %v, want %v (%+v)", file, got, want, inc)
+		}
+	}
+}
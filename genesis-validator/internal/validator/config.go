@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultConfigFile is loaded from the repository root when present
+const DefaultConfigFile = ".genesis-validator.json"
+
+// LoadConfigFile overlays settings from a JSON config file onto config.
+// Fields absent from the file keep their current values.
+func LoadConfigFile(path string, config *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	repoRoot := config.RepoRoot
+	config.RepoRoot = ""
+	if err := json.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	// A relative repoRoot in the file is relative to the file's directory
+	if config.RepoRoot == "" {
+		config.RepoRoot = repoRoot
+	} else if !filepath.IsAbs(config.RepoRoot) {
+		config.RepoRoot = filepath.Join(filepath.Dir(path), config.RepoRoot)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// repoFiles is the set of files validation sees: the files git tracks (plus
+// untracked files not ignored, when configured), or outside a git working
+// tree every file not excluded by .gitignore
+type repoFiles struct {
+	files []string // Slash-separated, relative to the repository root, sorted
+	set   pathSet  // files and their parent directories
+}
+
+// discoverFiles lists the repository's files from git, falling back to a
+// .gitignore-aware walk of the repository root
+func discoverFiles(ctx context.Context, ws *workspace, includeUntracked bool) (*repoFiles, error) {
+	var files []string
+	err := errNoGit
+	if repo := ws.Git(); repo != nil {
+		files, err = repo.ListFiles(includeUntracked)
+	}
+	if err != nil {
+		files, err = walkFiles(ctx, ws.fsys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Tracked files deleted from the working tree no longer exist
+	present := files[:0]
+	for _, file := range files {
+		if ws.Exists(filepath.FromSlash(file)) {
+			present = append(present, file)
+		}
+	}
+	sort.Strings(present)
+
+	return &repoFiles{files: present, set: newPathSet(present)}, nil
+}
+
+// errNoGit is returned for git operations on a tree that is not on disk
+var errNoGit = errors.New("not a git working tree")
+
+// walkFiles lists every file in fsys that .gitignore does not exclude
+func walkFiles(ctx context.Context, fsys fs.FS) ([]string, error) {
+	ignore := newGitIgnore(fsys)
+	var files []string
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil || p == "." {
+			return nil // Skip errors
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" || ignore.Ignored(p, true) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !ignore.Ignored(p, false) {
+			files = append(files, p)
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// fileCache holds the discovered files between refreshes
+type fileCache struct {
+	mu    sync.Mutex
+	files *repoFiles
+	err   error
+}
+
+// repoFiles returns the discovered files, discovering them on first use
+func (lv *LinkValidator) repoFiles(ctx context.Context) (*repoFiles, error) {
+	lv.cache.mu.Lock()
+	defer lv.cache.mu.Unlock()
+	if lv.cache.files == nil && lv.cache.err == nil {
+		lv.cache.files, lv.cache.err = discoverFiles(ctx, lv.ws, lv.config.IncludeUntracked)
+	}
+	return lv.cache.files, lv.cache.err
+}
+
+// refreshFiles discards the discovered files so the next use rediscovers them
+func (lv *LinkValidator) refreshFiles() {
+	lv.cache.mu.Lock()
+	lv.cache.files, lv.cache.err = nil, nil
+	lv.cache.mu.Unlock()
+}
+
+// inRepo reports whether a link target is a discovered file or directory, so
+// links to ignored or untracked local files are broken as they will be for
+// everyone else. Paths outside the repository are checked on disk.
+func (lv *LinkValidator) inRepo(p string) bool {
+	if !lv.ws.Exists(p) {
+		return false
+	}
+	files, err := lv.repoFiles(context.Background())
+	if err != nil {
+		return true
+	}
+
+	rel, ok := lv.ws.rel(p)
+	if !ok || rel == "." {
+		return true
+	}
+	return files.set.has(filepath.ToSlash(rel))
+}
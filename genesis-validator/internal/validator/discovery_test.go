@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGitIgnore(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, ".gitignore", "# build output\n/dist/\n*.log\nscratch/\n!keep.log\ndocs/**/draft-*.md\n")
+	writeTestFile(t, tmpDir, "docs/.gitignore", "private.md\n")
+
+	ignore := newGitIgnore(os.DirFS(tmpDir))
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"dist", true, true},
+		{"dist/app.js", false, true},
+		{"src/dist/app.js", false, false}, // Anchored to the root
+		{"debug.log", false, true},
+		{"logs/keep.log", false, false}, // Negated
+		{"notes/scratch/todo.md", false, true},
+		{"scratch", false, false}, // Directory-only pattern
+		{"docs/a/b/draft-1.md", false, true},
+		{"docs/private.md", false, true},
+		{"private.md", false, false}, // Rule from docs/.gitignore only applies beneath docs
+		{"README.md", false, false},
+	}
+	for _, tt := range tests {
+		if got := ignore.Ignored(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Ignored(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFindMarkdownFiles_Gitignore(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, ".gitignore", "build/\n")
+	writeTestFile(t, tmpDir, "README.md", "# Repo\n")
+	writeTestFile(t, tmpDir, "build/out.md", "# Generated\n")
+	writeTestFile(t, tmpDir, "_archive/old.md", "# Old\n")
+	chdir(t, tmpDir)
+
+	files, err := NewLinkValidator(DefaultConfig()).findMarkdownFiles(context.Background())
+	if err != nil {
+		t.Fatalf("findMarkdownFiles() error = %v", err)
+	}
+	if !reflect.DeepEqual(files, []string{"README.md"}) {
+		t.Errorf("findMarkdownFiles() = %v, want [README.md]", files)
+	}
+}
+
+func TestFindMarkdownFiles_Git(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{
+		".gitignore":     "local/\n",
+		"README.md":      "# Repo\n\n[Guide](docs/guide.md)\n[Notes](local/notes.md)\n[Draft](draft.md)\n",
+		"docs/guide.md":  "# Guide\n",
+		"docs/gone.md":   "# Deleted from the working tree\n",
+		"genesis/x.html": "",
+	})
+	writeTestFile(t, dir, "local/notes.md", "# Ignored\n")
+	writeTestFile(t, dir, "draft.md", "# Untracked\n")
+	if err := os.Remove(filepath.Join(dir, "docs", "gone.md")); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+
+	tests := []struct {
+		untracked bool
+		wantFiles []string
+		wantLinks []string
+	}{
+		{false, []string{"README.md", "docs/guide.md"}, []string{"local/notes.md", "draft.md"}},
+		{true, []string{"README.md", "docs/guide.md", "draft.md"}, []string{"local/notes.md"}},
+	}
+
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.IncludeUntracked = tt.untracked
+		lv := NewLinkValidator(config)
+
+		files, err := lv.findMarkdownFiles(context.Background())
+		if err != nil {
+			t.Fatalf("findMarkdownFiles() error = %v", err)
+		}
+		if !reflect.DeepEqual(files, tt.wantFiles) {
+			t.Errorf("untracked=%v findMarkdownFiles() = %v, want %v", tt.untracked, files, tt.wantFiles)
+		}
+
+		broken, err := lv.ValidateFileLinks("README.md")
+		if err != nil {
+			t.Fatalf("ValidateFileLinks() error = %v", err)
+		}
+		var links []string
+		for _, link := range broken {
+			links = append(links, link.LinkURL)
+		}
+		if !reflect.DeepEqual(links, tt.wantLinks) {
+			t.Errorf("untracked=%v broken links = %v, want %v", tt.untracked, links, tt.wantLinks)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExternalLinkConfig controls the opt-in check of http(s) links outside this
+// repository. Durations use Go syntax ("24h", "10s").
+type ExternalLinkConfig struct {
+	Enabled     bool     `json:"enabled"`
+	AllowHosts  []string `json:"allowHosts"`  // Host globs to check; empty checks every host not denied
+	DenyHosts   []string `json:"denyHosts"`   // Host globs never contacted; wins over allowHosts
+	CacheFile   string   `json:"cacheFile"`   // Where results are kept between runs; empty disables the cache
+	CacheTTL    string   `json:"cacheTTL"`    // How long a cached result is trusted
+	Timeout     string   `json:"timeout"`     // Per-request timeout
+	MaxPerHost  int      `json:"maxPerHost"`  // Concurrent requests per host
+	RatePerHost float64  `json:"ratePerHost"` // Requests started per second per host
+}
+
+// DefaultExternalLinkConfig returns the external link settings: disabled, so
+// validation stays offline unless asked
+func DefaultExternalLinkConfig() ExternalLinkConfig {
+	return ExternalLinkConfig{
+		CacheFile:   defaultExternalCacheFile(),
+		CacheTTL:    "24h",
+		Timeout:     "10s",
+		MaxPerHost:  2,
+		RatePerHost: 2,
+	}
+}
+
+// defaultExternalCacheFile returns the cache file in the user's cache
+// directory, so runs never leave it in the repository, or "" to disable the
+// cache when there is no such directory
+func defaultExternalCacheFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "genesis-validator", "external-links.json")
+}
+
+// externalCacheVersion is the current cache file format version
+const externalCacheVersion = 1
+
+// ExternalResult is the outcome of checking one external URL
+type ExternalResult struct {
+	Status    int       `json:"status,omitempty"` // Final HTTP status, 0 when no response was received
+	Error     string    `json:"error,omitempty"`  // Transport error, when no response was received
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// dead reports whether the URL is definitely gone, as opposed to failing in a
+// way that may be temporary
+func (r ExternalResult) dead() bool {
+	return r.Status == http.StatusNotFound || r.Status == http.StatusGone ||
+		(r.Status >= 400 && r.Status < 500 && r.Status != http.StatusTooManyRequests &&
+			r.Status != http.StatusUnauthorized && r.Status != http.StatusForbidden)
+}
+
+// ok reports whether the URL answered successfully
+func (r ExternalResult) ok() bool {
+	return r.Status >= 200 && r.Status < 400
+}
+
+// externalCache is the on-disk record of recent results, keyed by URL
+type externalCache struct {
+	Version int                       `json:"version"`
+	Results map[string]ExternalResult `json:"results"`
+}
+
+// ExternalChecker checks external URLs with per-host limits and a result cache
+type ExternalChecker struct {
+	config ExternalLinkConfig
+	client *http.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	hosts  map[string]*hostLimiter
+	cache  map[string]ExternalResult
+	misses int // Results fetched this run, so the cache is only rewritten when it changed
+}
+
+// hostLimiter bounds the concurrency and request rate against one host
+type hostLimiter struct {
+	slots    chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time // Earliest start of the next request
+}
+
+// NewExternalChecker creates an ExternalChecker and loads its cache
+func NewExternalChecker(config ExternalLinkConfig) (*ExternalChecker, error) {
+	defaults := DefaultExternalLinkConfig()
+	if config.CacheTTL == "" {
+		config.CacheTTL = defaults.CacheTTL
+	}
+	if config.Timeout == "" {
+		config.Timeout = defaults.Timeout
+	}
+	if config.MaxPerHost <= 0 {
+		config.MaxPerHost = defaults.MaxPerHost
+	}
+
+	ttl, err := time.ParseDuration(config.CacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid externalLinks.cacheTTL: %w", err)
+	}
+	timeout, err := time.ParseDuration(config.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid externalLinks.timeout: %w", err)
+	}
+
+	checker := &ExternalChecker{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+		ttl:    ttl,
+		now:    time.Now,
+		hosts:  make(map[string]*hostLimiter),
+		cache:  make(map[string]ExternalResult),
+	}
+	if err := checker.loadCache(); err != nil {
+		return nil, err
+	}
+	return checker, nil
+}
+
+// loadCache reads the cache file, if any. A cache from another format
+// version is ignored.
+func (c *ExternalChecker) loadCache() error {
+	if c.config.CacheFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.config.CacheFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var cache externalCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return fmt.Errorf("invalid external link cache %s: %w", c.config.CacheFile, err)
+	}
+	if cache.Version == externalCacheVersion && cache.Results != nil {
+		c.cache = cache.Results
+	}
+	return nil
+}
+
+// SaveCache writes results still within their TTL back to the cache file
+func (c *ExternalChecker) SaveCache() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.config.CacheFile == "" || c.misses == 0 {
+		return nil
+	}
+
+	cache := externalCache{Version: externalCacheVersion, Results: make(map[string]ExternalResult)}
+	for u, result := range c.cache {
+		if c.fresh(result) {
+			cache.Results[u] = result
+		}
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(c.config.CacheFile); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(c.config.CacheFile, append(data, '\n'), 0o644)
+}
+
+// fresh reports whether a cached result is within the TTL
+func (c *ExternalChecker) fresh(result ExternalResult) bool {
+	return c.now().Sub(result.CheckedAt) < c.ttl
+}
+
+// Allowed reports whether a host may be contacted under the allow and deny lists
+func (c *ExternalChecker) Allowed(host string) bool {
+	host = strings.ToLower(host)
+	if matchesHost(c.config.DenyHosts, host) {
+		return false
+	}
+	return len(c.config.AllowHosts) == 0 || matchesHost(c.config.AllowHosts, host)
+}
+
+// matchesHost reports whether host matches any glob, e.g. "*.github.io"
+func matchesHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the result for one URL, from the cache when fresh. The
+// fragment is not part of the request.
+func (c *ExternalChecker) Check(ctx context.Context, rawURL string) ExternalResult {
+	target := stripAnchor(rawURL)
+
+	c.mu.Lock()
+	cached, ok := c.cache[target]
+	c.mu.Unlock()
+	if ok && c.fresh(cached) {
+		return cached
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return ExternalResult{Error: err.Error(), CheckedAt: c.now()}
+	}
+
+	limiter := c.limiter(parsed.Host)
+	if !limiter.acquire(ctx) {
+		return ExternalResult{Error: ctx.Err().Error(), CheckedAt: c.now()}
+	}
+	result := c.fetch(ctx, target)
+	limiter.release()
+
+	// Only definite answers are cached; errors that may be temporary are
+	// retried next run
+	if result.ok() || result.dead() {
+		c.mu.Lock()
+		c.cache[target] = result
+		c.misses++
+		c.mu.Unlock()
+	}
+	return result
+}
+
+// fetch requests a URL with HEAD, falling back to GET for servers that
+// reject or mishandle HEAD
+func (c *ExternalChecker) fetch(ctx context.Context, target string) ExternalResult {
+	status, err := c.request(ctx, http.MethodHead, target)
+	if err != nil || status >= 400 {
+		status, err = c.request(ctx, http.MethodGet, target)
+	}
+
+	result := ExternalResult{Status: status, CheckedAt: c.now()}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// request sends one request and returns the response status
+func (c *ExternalChecker) request(ctx context.Context, method, target string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "genesis-validator (link checker)")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Allow connection reuse
+
+	return resp.StatusCode, nil
+}
+
+// limiter returns the limiter for a host, creating it on first use
+func (c *ExternalChecker) limiter(host string) *hostLimiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if limiter, ok := c.hosts[host]; ok {
+		return limiter
+	}
+	limiter := &hostLimiter{slots: make(chan struct{}, c.config.MaxPerHost)}
+	if c.config.RatePerHost > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / c.config.RatePerHost)
+	}
+	c.hosts[host] = limiter
+	return limiter
+}
+
+// acquire waits for a free slot and for the host's next permitted start
+// time. It returns false, holding no slot, if the context is cancelled first.
+func (l *hostLimiter) acquire(ctx context.Context) bool {
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-time.After(time.Until(start)):
+		return true
+	case <-ctx.Done():
+		<-l.slots
+		return false
+	}
+}
+
+// release frees the slot taken by acquire
+func (l *hostLimiter) release() {
+	<-l.slots
+}
+
+// externalLink is an external URL and every place it is linked from
+type externalLink struct {
+	url     string
+	sources []BrokenLink // SourceFile, Line, LinkText and LinkURL of each occurrence
+}
+
+// isExternalURL reports whether a link is an http(s) URL not already
+// resolved against the local tree as a link into this repository
+func (lv *LinkValidator) isExternalURL(u string) bool {
+	return (strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://")) && !lv.isRepoGitHubURL(u)
+}
+
+// ValidateExternalLinks checks every external URL in the markdown files.
+// URLs that are gone are returned as broken links; URLs that failed in a way
+// that may be temporary (timeouts, 5xx, 429, 401/403) become warnings.
+func (lv *LinkValidator) ValidateExternalLinks(ctx context.Context, checker *ExternalChecker, mdFiles []string) ([]BrokenLink, []Inconsistency, error) {
+	byURL := make(map[string]*externalLink)
+	var urls []string
+	for _, file := range mdFiles {
+		links, err := lv.extractLinks(file)
+		if err != nil {
+			continue // Skip files we can't read
+		}
+		for _, link := range links {
+			if !lv.isExternalURL(link.url) {
+				continue
+			}
+			parsed, err := url.Parse(link.url)
+			if err != nil || !checker.Allowed(parsed.Hostname()) {
+				continue
+			}
+			key := stripAnchor(link.url)
+			if byURL[key] == nil {
+				byURL[key] = &externalLink{url: key}
+				urls = append(urls, key)
+			}
+			byURL[key].sources = append(byURL[key].sources, BrokenLink{
+				SourceFile: file,
+				Line:       link.line,
+				LinkText:   link.text,
+				LinkURL:    link.url,
+			})
+		}
+	}
+	sort.Strings(urls)
+
+	results := make([]ExternalResult, len(urls))
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			results[i] = checker.Check(ctx, u)
+		}(i, u)
+	}
+	wg.Wait()
+
+	var broken []BrokenLink
+	var warnings []Inconsistency
+	for i, u := range urls {
+		result := results[i]
+		if result.ok() {
+			continue
+		}
+
+		reason := fmt.Sprintf("External URL returned HTTP %d", result.Status)
+		if result.Status == 0 {
+			reason = "External URL could not be reached: " + result.Error
+		}
+		for _, source := range byURL[u].sources {
+			if result.dead() {
+				source.Reason = reason
+				broken = append(broken, source)
+				continue
+			}
+			warnings = append(warnings, Inconsistency{
+				Type:        "external_link_error",
+				File:        source.SourceFile,
+				Line:        source.Line,
+				Description: reason + " (" + source.LinkURL + ")",
+				Location:    fmt.Sprintf("%s:%d", source.SourceFile, source.Line),
+			})
+		}
+	}
+
+	if err := checker.SaveCache(); err != nil {
+		return broken, warnings, fmt.Errorf("failed to save external link cache: %w", err)
+	}
+	return broken, warnings, ctx.Err()
+}
@@ -0,0 +1,220 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// externalTestServer serves /ok, /gone, /no-head (405 for HEAD), /busy (503)
+// and counts requests per path
+func externalTestServer(t *testing.T) (*httptest.Server, map[string]*int32) {
+	t.Helper()
+	counts := map[string]*int32{"/ok": new(int32), "/gone": new(int32), "/no-head": new(int32), "/busy": new(int32)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count, ok := counts[r.URL.Path]; ok {
+			atomic.AddInt32(count, 1)
+		}
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/busy":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, counts
+}
+
+func TestValidate_ExternalLinks(t *testing.T) {
+	server, counts := externalTestServer(t)
+	cacheHome := t.TempDir()
+	t.Setenv("HOME", cacheHome)
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	tmpDir, config := setupTestEnvironment(t)
+	config.ExternalLinks = DefaultExternalLinkConfig()
+	config.ExternalLinks.Enabled = true
+	config.ExternalLinks.RatePerHost = 0
+	writeTestFile(t, tmpDir, "docs/links.md", fmt.Sprintf(
+		"# Links\n\n[Ok](%[1]s/ok#top)\n[Gone](%[1]s/gone)\n[No HEAD](%[1]s/no-head)\n[Busy](%[1]s/busy)\n[Ok again](%[1]s/ok)\n",
+		server.URL))
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if len(result.BrokenLinks) != 1 || result.BrokenLinks[0].Line != 4 ||
+		result.BrokenLinks[0].Reason != "External URL returned HTTP 404" {
+		t.Errorf("BrokenLinks = %+v, want the /gone link on line 4", result.BrokenLinks)
+	}
+
+	var warnings []string
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "external_link_error" {
+			warnings = append(warnings, fmt.Sprintf("%s:%d", inc.File, inc.Line))
+		}
+	}
+	if strings.Join(warnings, ",") != filepath.Join("docs", "links.md")+":6" {
+		t.Errorf("external_link_error findings = %v, want the /busy link on line 6", warnings)
+	}
+
+	if n := atomic.LoadInt32(counts["/ok"]); n != 1 {
+		t.Errorf("/ok requested %d times, want 1 (HEAD, deduplicated across links)", n)
+	}
+	if n := atomic.LoadInt32(counts["/no-head"]); n != 2 {
+		t.Errorf("/no-head requested %d times, want 2 (HEAD then GET)", n)
+	}
+
+	// The cache goes to the user cache directory, never into the repository
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		t.Fatalf("UserCacheDir() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "genesis-validator", "external-links.json")); err != nil {
+		t.Errorf("external link cache not in the user cache directory: %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(tmpDir, "*cache*")); len(files) != 0 {
+		t.Errorf("external link cache written into the repository: %v", files)
+	}
+}
+
+func TestValidate_ExternalLinksOffByDefault(t *testing.T) {
+	server, counts := externalTestServer(t)
+	tmpDir, config := setupTestEnvironment(t)
+	writeTestFile(t, tmpDir, "docs/links.md", "# Links\n\n[Gone]("+server.URL+"/gone)\n")
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(result.BrokenLinks) != 0 || atomic.LoadInt32(counts["/gone"]) != 0 {
+		t.Errorf("external link was checked with the check disabled: %+v", result.BrokenLinks)
+	}
+}
+
+func TestExternalChecker_Cache(t *testing.T) {
+	server, counts := externalTestServer(t)
+	config := DefaultExternalLinkConfig()
+	config.CacheFile = filepath.Join(t.TempDir(), "cache", "links.json")
+
+	checker, err := NewExternalChecker(config)
+	if err != nil {
+		t.Fatalf("NewExternalChecker() error = %v", err)
+	}
+	checker.Check(context.Background(), server.URL+"/gone")
+	checker.Check(context.Background(), server.URL+"/busy")
+	if err := checker.SaveCache(); err != nil {
+		t.Fatalf("SaveCache() error = %v", err)
+	}
+
+	// A new run answers from the cache; the temporary failure is retried
+	reloaded, err := NewExternalChecker(config)
+	if err != nil {
+		t.Fatalf("NewExternalChecker() error = %v", err)
+	}
+	if got := reloaded.Check(context.Background(), server.URL+"/gone"); got.Status != http.StatusNotFound {
+		t.Errorf("cached status = %d, want 404", got.Status)
+	}
+	reloaded.Check(context.Background(), server.URL+"/busy")
+	if n := atomic.LoadInt32(counts["/gone"]); n != 2 {
+		t.Errorf("/gone requested %d times, want 2 (HEAD and GET once, then cached)", n)
+	}
+	if n := atomic.LoadInt32(counts["/busy"]); n != 4 {
+		t.Errorf("/busy requested %d times, want 4 (not cached)", n)
+	}
+
+	// Once the TTL has passed the URL is fetched again
+	reloaded.now = func() time.Time { return time.Now().Add(25 * time.Hour) }
+	reloaded.Check(context.Background(), server.URL+"/gone")
+	if n := atomic.LoadInt32(counts["/gone"]); n != 4 {
+		t.Errorf("/gone requested %d times after the TTL, want 4", n)
+	}
+}
+
+func TestExternalChecker_Allowed(t *testing.T) {
+	checker, err := NewExternalChecker(ExternalLinkConfig{
+		AllowHosts: []string{"*.github.io", "example.com"},
+		DenyHosts:  []string{"blocked.github.io"},
+	})
+	if err != nil {
+		t.Fatalf("NewExternalChecker() error = %v", err)
+	}
+
+	tests := map[string]bool{
+		"bordenet.github.io": true,
+		"Example.com":        true,
+		"blocked.github.io":  false,
+		"other.org":          false,
+	}
+	for host, want := range tests {
+		if got := checker.Allowed(host); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestExternalChecker_PerHostLimits(t *testing.T) {
+	var mu sync.Mutex
+	var active, peak int
+	var starts []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		if active > peak {
+			peak = active
+		}
+		starts = append(starts, time.Now())
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	checker, err := NewExternalChecker(ExternalLinkConfig{MaxPerHost: 1, RatePerHost: 20})
+	if err != nil {
+		t.Fatalf("NewExternalChecker() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			checker.Check(context.Background(), fmt.Sprintf("%s/page%d", server.URL, i))
+		}(i)
+	}
+	wg.Wait()
+
+	if peak != 1 {
+		t.Errorf("peak concurrent requests = %d, want 1", peak)
+	}
+	if len(starts) != 4 {
+		t.Fatalf("requests = %d, want 4", len(starts))
+	}
+	if elapsed := starts[3].Sub(starts[0]); elapsed < 140*time.Millisecond {
+		t.Errorf("4 requests at 20/s started within %v, want at least 150ms", elapsed)
+	}
+}
@@ -0,0 +1,362 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fix is a mechanical edit that resolves a finding: a replacement within one
+// line, or whole lines inserted before it
+type Fix struct {
+	Title  string // Human-readable description of the edit
+	File   string // File to edit
+	Line   int    // 1-based line number of the edit; an insertion may use the line after the last
+	Column int    // 1-based byte column of Old on the line, 0 when unknown
+	Old    string // Exact text to replace on that line; "" inserts New as lines before it
+	New    string // Replacement text
+}
+
+// linkFix builds a Fix that rewrites a link's target
+func linkFix(sourceFile string, link linkInfo, newURL, title string) *Fix {
+	return &Fix{
+		Title:  title,
+		File:   sourceFile,
+		Line:   link.line,
+		Column: link.col + len(link.text) + 2, // Past "[text"
+		Old:    "](" + link.url + ")",
+		New:    "](" + newURL + ")",
+	}
+}
+
+// listItemPattern matches the marker of a markdown list item
+var listItemPattern = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])(\s+)`)
+
+// childLinkFix builds a Fix that links a parent index to a sub-document. The
+// link follows the parent's last list item or table row linking into the
+// child's folder, or is appended as a list item.
+func childLinkFix(parent string, content []byte, links []linkInfo, child, title string) Fix {
+	lines := splitLines(content)
+	rel := relativeLink(parent, child)
+
+	var sibling *linkInfo
+	for i, link := range links {
+		url := stripAnchor(link.url)
+		if url == "" || strings.Contains(url, "://") || link.line > len(lines) {
+			continue
+		}
+		if filepath.Dir(filepath.Join(filepath.Dir(parent), filepath.FromSlash(url))) == filepath.Dir(child) {
+			sibling = &links[i]
+		}
+	}
+
+	fix := Fix{Title: "Link to " + displayPath(child), File: parent}
+	if sibling != nil {
+		if strings.HasPrefix(sibling.url, "./") && !strings.HasPrefix(rel, "../") {
+			rel = "./" + rel
+		}
+		line := lines[sibling.line-1]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "|") {
+			cells := max(strings.Count(trimmed, "|")-1, 1)
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			link := "[" + strings.ReplaceAll(title, "|", `\|`) + "](" + rel + ")"
+			fix.Line, fix.New = sibling.line+1, indent+"| "+link+" |"+strings.Repeat(" |", cells-1)
+			return fix
+		}
+		if m := listItemPattern.FindStringSubmatch(line); m != nil {
+			marker := m[2]
+			if n, err := strconv.Atoi(marker[:len(marker)-1]); err == nil {
+				marker = strconv.Itoa(n+1) + marker[len(marker)-1:]
+			}
+			fix.Line, fix.New = sibling.line+1, m[1]+marker+m[3]+"["+title+"]("+rel+")"
+			return fix
+		}
+	}
+
+	fix.Line, fix.New = len(lines)+1, "- ["+title+"]("+rel+")"
+	if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+		fix.New = "\n" + fix.New
+	}
+	return fix
+}
+
+// breadcrumbFix builds a Fix that inserts a breadcrumb line below the title
+// of a document, or at its top when it has none
+func breadcrumbFix(file string, content []byte, crumb string) Fix {
+	lines := splitLines(content)
+	first := 0
+	for first < len(lines) && strings.TrimSpace(lines[first]) == "" {
+		first++
+	}
+
+	fix := Fix{Title: "Add breadcrumb: " + crumb, File: file}
+	switch {
+	case first == len(lines) || !headingPattern.MatchString(lines[first]):
+		fix.Line, fix.New = first+1, crumb
+		if first < len(lines) {
+			fix.New += "\n"
+		}
+	case first+1 < len(lines) && strings.TrimSpace(lines[first+1]) == "":
+		// Keep the blank line under the title and add one under the breadcrumb
+		fix.Line, fix.New = first+3, crumb
+		if first+2 < len(lines) {
+			fix.New += "\n"
+		}
+	default:
+		fix.Line, fix.New = first+2, "\n"+crumb
+		if first+1 < len(lines) {
+			fix.New += "\n"
+		}
+	}
+	return fix
+}
+
+// documentTitle returns the first top-level heading of a document, its first
+// heading, or its file name
+func documentTitle(file string, content []byte) string {
+	headings, _ := extractHeadings(strings.NewReader(string(content)))
+	for _, heading := range headings {
+		if heading.Level == 1 {
+			return heading.Text
+		}
+	}
+	if len(headings) > 0 {
+		return headings[0].Text
+	}
+	return strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+}
+
+// variableNamePattern matches the name of a template variable
+var variableNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// LoadVariables reads a JSON object mapping template variable names, such
+// as PROJECT_NAME, to the values that replace their {{PLACEHOLDERS}}
+func LoadVariables(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vars map[string]string
+	if err := json.Unmarshal(data, &vars); err != nil {
+		return nil, fmt.Errorf("invalid variables file %s: %w", path, err)
+	}
+	for name := range vars {
+		if !variableNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid variables file %s: %q is not a variable name like PROJECT_NAME", path, name)
+		}
+	}
+	return vars, nil
+}
+
+// placeholderFixes builds Fixes that replace the {{VARIABLES}} of a document
+// that have a value
+func placeholderFixes(file string, content []byte, vars map[string]string) []Fix {
+	placeholders, _ := findPlaceholders(strings.NewReader(string(content)))
+	var fixes []Fix
+	for _, p := range placeholders {
+		value, ok := vars[p.Name]
+		if !ok {
+			continue
+		}
+		fixes = append(fixes, Fix{
+			Title:  "Replace " + p.Text,
+			File:   file,
+			Line:   p.Line,
+			Column: p.Column + 1,
+			Old:    p.Text,
+			New:    value,
+		})
+	}
+	return fixes
+}
+
+// CollectFixes returns every mechanical fix available for a result: link
+// rewrites for broken links, inserted breadcrumbs and parent links for split
+// documents, and, outside the genesis root, placeholders replaced by vars
+func (v *Validator) CollectFixes(ctx context.Context, result *ValidationResult, vars map[string]string) ([]Fix, error) {
+	var fixes []Fix
+	for _, link := range result.BrokenLinks {
+		if link.Fix != nil {
+			fixes = append(fixes, *link.Fix)
+		}
+	}
+
+	mdFiles, err := v.linkValidator.findMarkdownFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(mdFiles)
+
+	// Only fix findings the result still reports, after baselines and suppressions
+	reported := make(map[findingKey]bool)
+	for _, inc := range result.Inconsistencies {
+		reported[keyOf(inc.Type, inc.File, inc.Line, inc.Description)] = true
+	}
+	crumbs, err := v.checkBreadcrumbs(mdFiles)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range crumbs {
+		if reported[keyOf(f.Type, f.File, f.Line, f.Description)] {
+			fixes = append(fixes, f.fixes...)
+		}
+	}
+
+	if len(vars) > 0 {
+		for _, file := range mdFiles {
+			if inGenesisRoot(v.config.GenesisRoot, file) {
+				continue
+			}
+			content, err := v.ws.ReadFile(file)
+			if err != nil {
+				continue // Deleted since discovery
+			}
+			fixes = append(fixes, placeholderFixes(file, content, vars)...)
+		}
+	}
+	return fixes, nil
+}
+
+// FixPatch is the patch that applies the fixes collected for a result
+type FixPatch struct {
+	Patch   string   // Unified diff for git apply, "" when there is nothing to fix
+	Files   []string // Slash-separated files the patch changes
+	Applied []Fix
+	Skipped []Fix // Fixes that overlap an earlier fix or no longer match their file
+}
+
+// FixPatch collects the fixes for a result and merges them, file by file,
+// into a single unified diff. Fixes on the same line are combined; a fix
+// that overlaps an earlier one is skipped.
+func (v *Validator) FixPatch(ctx context.Context, result *ValidationResult, vars map[string]string) (*FixPatch, error) {
+	fixes, err := v.CollectFixes(ctx, result, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	byFile := make(map[string][]Fix)
+	for _, fix := range fixes {
+		path := displayPath(fix.File)
+		byFile[path] = append(byFile[path], fix)
+	}
+	paths := make([]string, 0, len(byFile))
+	for path := range byFile {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	patch := &FixPatch{}
+	var diff strings.Builder
+	for _, path := range paths {
+		content, err := v.ws.ReadFile(filepath.FromSlash(path))
+		if err != nil {
+			patch.Skipped = append(patch.Skipped, byFile[path]...)
+			continue
+		}
+		ops, applied, skipped := mergeFixes(content, byFile[path])
+		patch.Applied = append(patch.Applied, applied...)
+		patch.Skipped = append(patch.Skipped, skipped...)
+		if text := formatDiff(path, ops); text != "" {
+			diff.WriteString(text)
+			patch.Files = append(patch.Files, path)
+		}
+	}
+	patch.Patch = diff.String()
+	return patch, nil
+}
+
+// suggestLinkFix proposes a new target for a broken relative link when exactly
+// one file in the repository has the same name, ignoring case
+func suggestLinkFix(sourceFile string, link linkInfo, files []string) *Fix {
+	target, anchor := link.url, ""
+	if idx := strings.Index(target, "#"); idx != -1 {
+		target, anchor = target[:idx], target[idx:]
+	}
+	if target == "" || strings.Contains(target, "://") {
+		return nil
+	}
+
+	name := strings.ToLower(path.Base(filepath.ToSlash(target)))
+	var match string
+	for _, file := range files {
+		if strings.ToLower(path.Base(file)) != name {
+			continue
+		}
+		if match != "" {
+			return nil // Ambiguous
+		}
+		match = file
+	}
+	if match == "" {
+		return nil
+	}
+
+	rel := relativeLink(sourceFile, match)
+	if rel == target {
+		return nil
+	}
+	return linkFix(sourceFile, link, rel+anchor, "Change link to "+rel+anchor)
+}
+
+// suggestAnchorFix proposes the closest existing heading anchor for a missing
+// anchor, accepting only near misses
+func suggestAnchorFix(sourceFile string, link linkInfo, headings []Heading) *Fix {
+	idx := strings.Index(link.url, "#")
+	if idx == -1 {
+		return nil
+	}
+	prefix, anchor := link.url[:idx], strings.ToLower(link.url[idx+1:])
+
+	best, bestDist := "", 4
+	for _, heading := range headings {
+		if d := editDistance(anchor, heading.Anchor); d < bestDist {
+			best, bestDist = heading.Anchor, d
+		}
+	}
+	if best == "" {
+		return nil
+	}
+
+	return linkFix(sourceFile, link, prefix+"#"+best, "Change anchor to #"+best)
+}
+
+// relativeLink returns the slash-separated path from sourceFile's directory to target
+func relativeLink(sourceFile, target string) string {
+	rel, err := filepath.Rel(filepath.Dir(sourceFile), target)
+	if err != nil {
+		return filepath.ToSlash(target)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(br)]
+}
@@ -0,0 +1,242 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// GitRepo runs git commands against a repository working tree
+type GitRepo struct {
+	dir string
+}
+
+// NewGitRepo creates a new GitRepo rooted at dir
+func NewGitRepo(dir string) *GitRepo {
+	return &GitRepo{dir: dir}
+}
+
+// StagedChange describes a path changed in the git index relative to HEAD
+type StagedChange struct {
+	Status  byte   // 'A', 'M', 'D', 'R', 'C', 'T'
+	Path    string // Repo-relative path in the index
+	OldPath string // Previous path for renames and copies
+}
+
+// run executes a git command and returns its stdout
+func (g *GitRepo) run(args ...string) ([]byte, error) {
+	return g.runInput(nil, args...)
+}
+
+// runInput executes a git command with input on stdin and returns its stdout
+func (g *GitRepo) runInput(input []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", g.dir}, args...)...)
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// StagedChanges lists paths added, modified, renamed or deleted in the index
+func (g *GitRepo) StagedChanges() ([]StagedChange, error) {
+	out, err := g.run("diff", "--cached", "--name-status", "-z", "-M")
+	if err != nil {
+		return nil, err
+	}
+	return parseNameStatus(out)
+}
+
+// parseNameStatus parses `git diff --name-status -z` output
+func parseNameStatus(out []byte) ([]StagedChange, error) {
+	fields := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	if len(fields) == 1 && fields[0] == "" {
+		return nil, nil
+	}
+
+	var changes []StagedChange
+	for i := 0; i < len(fields); {
+		status := fields[i]
+		if status == "" {
+			return nil, fmt.Errorf("malformed name-status output at field %d", i)
+		}
+		change := StagedChange{Status: status[0]}
+
+		switch change.Status {
+		case 'R', 'C':
+			if i+2 >= len(fields) {
+				return nil, fmt.Errorf("truncated rename entry for status %s", status)
+			}
+			change.OldPath = fields[i+1]
+			change.Path = fields[i+2]
+			i += 3
+		default:
+			if i+1 >= len(fields) {
+				return nil, fmt.Errorf("truncated entry for status %s", status)
+			}
+			change.Path = fields[i+1]
+			i += 2
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// ReadStaged returns the content of path as recorded in the index
+func (g *GitRepo) ReadStaged(p string) ([]byte, error) {
+	return g.run("cat-file", "blob", ":"+p)
+}
+
+// StagedFiles lists every path recorded in the index
+func (g *GitRepo) StagedFiles() ([]string, error) {
+	out, err := g.run("ls-files", "-z", "--cached", "--full-name")
+	if err != nil {
+		return nil, err
+	}
+	return splitNul(out), nil
+}
+
+// ListFiles lists tracked files relative to the repository directory, and
+// untracked files not excluded by .gitignore when includeUntracked is set
+func (g *GitRepo) ListFiles(includeUntracked bool) ([]string, error) {
+	args := []string{"ls-files", "-z", "--cached"}
+	if includeUntracked {
+		args = append(args, "--others", "--exclude-standard")
+	}
+	out, err := g.run(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmerged paths are listed once per stage
+	var files []string
+	seen := make(map[string]bool)
+	for _, file := range splitNul(out) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// splitNul splits NUL-terminated git output into fields
+func splitNul(out []byte) []string {
+	trimmed := strings.TrimSuffix(string(out), "\x00")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "\x00")
+}
+
+// pathSet is a set of slash-separated paths that also answers for parent directories
+type pathSet map[string]bool
+
+// newPathSet builds a pathSet from file paths, adding every parent directory
+func newPathSet(files []string) pathSet {
+	set := make(pathSet, len(files))
+	for _, file := range files {
+		for p := path.Clean(file); p != "." && p != "/" && !set[p]; p = path.Dir(p) {
+			set[p] = true
+		}
+	}
+	return set
+}
+
+// has reports whether p names a file or directory in the set
+func (s pathSet) has(p string) bool {
+	return s[path.Clean(strings.ReplaceAll(p, "\\", "/"))]
+}
+
+// ResolveCommit returns the commit SHA a branch, tag or abbreviated SHA names
+func (g *GitRepo) ResolveCommit(ref string) (string, error) {
+	out, err := g.run("rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// IsShallow reports whether the repository is a shallow clone, which lacks
+// history and usually other branches and tags
+func (g *GitRepo) IsShallow() bool {
+	out, err := g.run("rev-parse", "--is-shallow-repository")
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// ObjectType returns "blob" or "tree" for a path at a commit
+func (g *GitRepo) ObjectType(rev, p string) (string, error) {
+	out, err := g.run("cat-file", "-t", rev+":"+p)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ReadBlob returns the content of a file at a commit
+func (g *GitRepo) ReadBlob(rev, p string) ([]byte, error) {
+	return g.run("cat-file", "blob", rev+":"+p)
+}
+
+// TreeFS returns the files of a commit as a read-only file system, so a
+// revision can be validated without checking it out. Submodules and symbolic
+// links are left out.
+func (g *GitRepo) TreeFS(rev string) (fs.FS, error) {
+	sha, err := g.ResolveCommit(rev)
+	if err != nil {
+		return nil, fmt.Errorf("unknown revision %s", rev)
+	}
+	out, err := g.run("ls-tree", "-r", "-z", sha)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries are "<mode> <type> <object>\t<path>"
+	var paths []string
+	var objects bytes.Buffer
+	for _, entry := range splitNul(out) {
+		meta, p, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 || fields[1] != "blob" || fields[0] == "120000" {
+			continue
+		}
+		paths = append(paths, p)
+		objects.WriteString(fields[2] + "\n")
+	}
+	if len(paths) == 0 {
+		return newMemFS(nil), nil
+	}
+
+	// Read every blob with one process: "<object> <type> <size>\n<content>\n"
+	out, err = g.runInput(objects.Bytes(), "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte, len(paths))
+	for _, p := range paths {
+		header, rest, ok := bytes.Cut(out, []byte("\n"))
+		fields := strings.Fields(string(header))
+		if !ok || len(fields) != 3 {
+			return nil, fmt.Errorf("malformed git cat-file output for %s", p)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil || size+1 > len(rest) {
+			return nil, fmt.Errorf("malformed git cat-file output for %s", p)
+		}
+		files[p] = rest[:size]
+		out = rest[size+1:]
+	}
+	return newMemFS(files), nil
+}
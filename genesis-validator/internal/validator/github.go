@@ -0,0 +1,231 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// gitHubURLPattern matches file and directory URLs on GitHub, capturing the
+// owner/name, the view and the ref followed by the path:
+//
+//	https://github.com/bordenet/genesis/blob/v1.2/genesis/START-HERE.md#L10-L20
+//	https://raw.githubusercontent.com/bordenet/genesis/main/README.md
+var gitHubURLPattern = regexp.MustCompile(`^https?://(?:www\.)?github\.com/([^/]+/[^/]+)/(blob|tree|raw|blame)/([^?#]+)|^https?://raw\.githubusercontent\.com/([^/]+/[^/]+)/([^?#]+)`)
+
+// commitSHAPattern matches a full or abbreviated commit SHA
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// lineAnchorPattern matches GitHub line anchors: #L10, #L10-L20, #L10C3-L12C8
+var lineAnchorPattern = regexp.MustCompile(`^L(\d+)(?:C\d+)?(?:-L(\d+)(?:C\d+)?)?$`)
+
+// gitHubLink is a GitHub URL pointing into this repository. The ref and the
+// path are not yet separated, since branch names may contain slashes.
+type gitHubLink struct {
+	view     string // "blob", "tree", "raw" or "blame"
+	refPath  string // Ref followed by the repo path, e.g. "v1.2/genesis/START-HERE.md"
+	fragment string
+}
+
+// gitHubRefs resolves refs named in GitHub URLs against the local git object
+// database, caching lookups
+type gitHubRefs struct {
+	repo *GitRepo // nil when validating a tree that is not a git working tree
+
+	mu      sync.Mutex
+	commits map[string]string // Ref -> commit SHA, "" when unknown
+	shallow *bool             // Whether the repository is a shallow clone, once asked
+}
+
+// newGitHubRefs creates a resolver for a repository
+func newGitHubRefs(repo *GitRepo) *gitHubRefs {
+	return &gitHubRefs{repo: repo, commits: make(map[string]string)}
+}
+
+// commit resolves a branch, tag or SHA, also trying it as a branch of origin
+// for refs that were fetched but never checked out
+func (r *gitHubRefs) commit(ref string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sha, ok := r.commits[ref]; ok {
+		return sha
+	}
+	sha := ""
+	for _, candidate := range []string{ref, "origin/" + ref} {
+		if resolved, err := r.repo.ResolveCommit(candidate); err == nil {
+			sha = resolved
+			break
+		}
+	}
+	r.commits[ref] = sha
+	return sha
+}
+
+// unavailable reports whether a ref that did not resolve may just be missing
+// from this clone: the repository is shallow, as CI checkouts usually are, or
+// the ref is a commit SHA whose object was never fetched
+func (r *gitHubRefs) unavailable(ref string) bool {
+	if commitSHAPattern.MatchString(ref) {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shallow == nil {
+		shallow := r.repo.IsShallow()
+		r.shallow = &shallow
+	}
+	return *r.shallow
+}
+
+// split separates the ref from the path by trying ever longer prefixes of
+// refPath as a ref. It returns the ref, its commit and the path.
+func (r *gitHubRefs) split(refPath string) (ref, sha, filePath string, ok bool) {
+	segments := strings.Split(strings.Trim(refPath, "/"), "/")
+	for i := 1; i <= len(segments); i++ {
+		ref = strings.Join(segments[:i], "/")
+		if sha = r.commit(ref); sha != "" {
+			return ref, sha, strings.Join(segments[i:], "/"), true
+		}
+	}
+	return segments[0], "", "", false
+}
+
+// parseGitHubURL recognizes GitHub URLs pointing into the configured
+// repository. Other URLs, including other repositories, are external.
+func (lv *LinkValidator) parseGitHubURL(url string) (*gitHubLink, bool) {
+	match := gitHubURLPattern.FindStringSubmatch(url)
+	if match == nil {
+		return nil, false
+	}
+
+	link := &gitHubLink{view: match[2], refPath: match[3]}
+	repo := match[1]
+	if match[4] != "" {
+		link.view, link.refPath, repo = "raw", match[5], match[4]
+	}
+	if !strings.EqualFold(repo, lv.config.GitHubRepo) {
+		return nil, false
+	}
+
+	if idx := strings.Index(url, "#"); idx != -1 {
+		link.fragment = url[idx+1:]
+	}
+	// Remove trailing characters that might have been captured
+	link.refPath = strings.TrimRight(link.refPath, `">`)
+	return link, true
+}
+
+// isRepoGitHubURL reports whether a URL points into this repository on GitHub
+func (lv *LinkValidator) isRepoGitHubURL(url string) bool {
+	_, ok := lv.parseGitHubURL(url)
+	return ok
+}
+
+// defaultBranchPath returns the repo path of a link on the default branch,
+// which is checked against the working tree since that is what the branch
+// will contain once the change lands
+func (lv *LinkValidator) defaultBranchPath(link *gitHubLink) (string, bool) {
+	branch := lv.config.DefaultBranch
+	if link.refPath == branch {
+		return ".", true
+	}
+	if strings.HasPrefix(link.refPath, branch+"/") {
+		return strings.TrimPrefix(link.refPath, branch+"/"), true
+	}
+	return "", false
+}
+
+// validateGitHubLink validates a GitHub URL pointing to this repo. Links on
+// the default branch are checked against the working tree; links on other
+// branches, tags and commits against that ref in the local git repository,
+// and skipped when the ref is not available locally. Line anchors must fall
+// within the file.
+func (lv *LinkValidator) validateGitHubLink(sourceFile string, link linkInfo) *BrokenLink {
+	gh, ok := lv.parseGitHubURL(link.url)
+	if !ok {
+		return nil
+	}
+	broken := func(reason string) *BrokenLink {
+		return &BrokenLink{
+			SourceFile: sourceFile,
+			Line:       link.line,
+			LinkText:   link.text,
+			LinkURL:    link.url,
+			Reason:     reason,
+		}
+	}
+
+	if localPath, ok := lv.defaultBranchPath(gh); ok {
+		// Check if file/directory exists
+		if !lv.exists(localPath) {
+			return broken("GitHub URL points to non-existent path: " + localPath)
+		}
+		if reason := lv.checkLineAnchor(gh, func() ([]byte, error) { return lv.read(localPath) }); reason != "" {
+			return broken(reason)
+		}
+		return nil
+	}
+	if lv.refs.repo == nil {
+		return nil // Other refs can't be checked without a git repository
+	}
+
+	ref, sha, refPath, ok := lv.refs.split(gh.refPath)
+	if !ok && lv.refs.unavailable(ref) {
+		return nil // Can't be checked until the ref is fetched
+	}
+	if !ok {
+		return broken("GitHub URL points to a ref not found in the local repository: " + ref)
+	}
+	if refPath == "" {
+		return nil // The root of the tree at that ref
+	}
+	if _, err := lv.refs.repo.ObjectType(sha, refPath); err != nil {
+		return broken(fmt.Sprintf("GitHub URL points to path missing at %s: %s", ref, refPath))
+	}
+	if reason := lv.checkLineAnchor(gh, func() ([]byte, error) { return lv.refs.repo.ReadBlob(sha, refPath) }); reason != "" {
+		return broken(reason)
+	}
+	return nil
+}
+
+// checkLineAnchor verifies a #L10-L20 anchor against the file's length and
+// returns why it is invalid, or "" when it is valid or not a line anchor
+func (lv *LinkValidator) checkLineAnchor(gh *gitHubLink, read func() ([]byte, error)) string {
+	match := lineAnchorPattern.FindStringSubmatch(gh.fragment)
+	if match == nil || gh.view == "tree" {
+		return ""
+	}
+
+	start, _ := strconv.Atoi(match[1])
+	end := start
+	if match[2] != "" {
+		end, _ = strconv.Atoi(match[2])
+	}
+	if start < 1 || end < start {
+		return fmt.Sprintf("GitHub line anchor #%s is not a valid line range", gh.fragment)
+	}
+
+	content, err := read()
+	if err != nil {
+		return "" // A directory; the path itself was already checked
+	}
+	if lines := countLines(content); end > lines {
+		return fmt.Sprintf("GitHub line anchor #%s is past the end of the file (%d lines)", gh.fragment, lines)
+	}
+	return ""
+}
+
+// countLines counts lines the way GitHub numbers them: a final line without
+// a trailing newline still counts
+func countLines(content []byte) int {
+	n := bytes.Count(content, []byte("\n"))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateGitHubLink_Refs(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{
+		"docs/old.md": "one\ntwo\nthree\n",
+		"README.md":   "# Repo\n",
+	})
+	runGit(t, dir, "tag", "v1.0")
+	runGit(t, dir, "branch", "feature/split")
+	runGit(t, dir, "mv", "docs/old.md", "docs/new.md")
+	runGit(t, dir, "commit", "-q", "-m", "rename")
+	chdir(t, dir)
+
+	lv := NewLinkValidator(DefaultConfig())
+	base := "https://github.com/bordenet/genesis/"
+
+	tests := []struct {
+		url        string
+		wantReason string // "" when the link is valid
+	}{
+		{base + "blob/main/docs/new.md#L2", ""},
+		{base + "blob/main/docs/new.md#L2-L4", "GitHub line anchor #L2-L4 is past the end of the file (3 lines)"},
+		{base + "blob/main/docs/old.md", "GitHub URL points to non-existent path: docs/old.md"},
+		{base + "tree/main/docs", ""},
+		{base + "blob/v1.0/docs/old.md#L1-L3", ""},
+		{base + "blob/v1.0/docs/new.md", "GitHub URL points to path missing at v1.0: docs/new.md"},
+		{base + "blob/feature/split/docs/old.md#L3C1-L3C5", ""},
+		{base + "blob/v1.0/docs/old.md#L3-L2", "GitHub line anchor #L3-L2 is not a valid line range"},
+		{base + "blob/v2.0/README.md", "GitHub URL points to a ref not found in the local repository: v2.0"},
+		{base + "blob/0123456789abcdef0123456789abcdef01234567/README.md", ""}, // Commit never fetched
+		{"https://raw.githubusercontent.com/bordenet/genesis/v1.0/docs/old.md", ""},
+		{"https://github.com/someone/else/blob/v9/missing.md", ""},
+	}
+
+	for _, tt := range tests {
+		got := ""
+		if broken := lv.validateLink("README.md", linkInfo{url: tt.url, line: 1}); broken != nil {
+			got = broken.Reason
+		}
+		if got != tt.wantReason {
+			t.Errorf("validateLink(%s) reason = %q, want %q", tt.url, got, tt.wantReason)
+		}
+	}
+}
+
+func TestValidateGitHubLink_ShallowClone(t *testing.T) {
+	origin := setupGitRepo(t, map[string]string{"docs/old.md": "one\ntwo\n", "README.md": "# Repo\n"})
+	runGit(t, origin, "tag", "v1.0")
+	runGit(t, origin, "branch", "release")
+	runGit(t, origin, "mv", "docs/old.md", "docs/new.md")
+	runGit(t, origin, "commit", "-q", "-m", "rename")
+
+	dir := filepath.Join(t.TempDir(), "clone")
+	runGit(t, origin, "clone", "-q", "--depth", "1", "--no-tags", "file://"+origin, dir)
+	chdir(t, dir)
+
+	lv := NewLinkValidator(DefaultConfig())
+	base := "https://github.com/bordenet/genesis/"
+
+	tests := []struct {
+		url        string
+		wantReason string // "" when the link is valid or can't be checked
+	}{
+		{base + "blob/v1.0/docs/old.md", ""},      // Tag not fetched
+		{base + "blob/release/docs/old.md", ""},   // Branch not fetched
+		{base + "blob/v1.0/docs/anything.md", ""}, // Not checked without the tag either
+		{base + "blob/main/docs/old.md", "GitHub URL points to non-existent path: docs/old.md"},
+		{base + "blob/HEAD/docs/old.md", "GitHub URL points to path missing at HEAD: docs/old.md"},
+		{base + "blob/HEAD/docs/new.md#L5", "GitHub line anchor #L5 is past the end of the file (2 lines)"},
+	}
+
+	for _, tt := range tests {
+		got := ""
+		if broken := lv.validateLink("README.md", linkInfo{url: tt.url, line: 1}); broken != nil {
+			got = broken.Reason
+		}
+		if got != tt.wantReason {
+			t.Errorf("validateLink(%s) reason = %q, want %q", tt.url, got, tt.wantReason)
+		}
+	}
+}
+
+func TestValidateGitHubLink_SHA(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{"a.md": "# A\n"})
+	chdir(t, dir)
+
+	sha, err := NewGitRepo(".").ResolveCommit("HEAD")
+	if err != nil {
+		t.Fatalf("ResolveCommit() error = %v", err)
+	}
+
+	lv := NewLinkValidator(DefaultConfig())
+	for _, ref := range []string{sha, sha[:8]} {
+		url := "https://github.com/bordenet/genesis/blob/" + ref + "/a.md#L1"
+		if broken := lv.validateLink("a.md", linkInfo{url: url, line: 1}); broken != nil {
+			t.Errorf("validateLink(%s) = %s, want valid", url, broken.Reason)
+		}
+	}
+}
+
+func TestValidateGitHubLink_ConfiguredRepo(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{"a.md": "# A\n"})
+	chdir(t, dir)
+
+	config := DefaultConfig()
+	config.GitHubRepo = "acme/docs"
+	config.DefaultBranch = "trunk"
+	lv := NewLinkValidator(config)
+
+	broken := lv.validateLink("a.md", linkInfo{url: "https://github.com/acme/docs/blob/trunk/b.md", line: 1})
+	if broken == nil || !strings.Contains(broken.Reason, "non-existent path: b.md") {
+		t.Errorf("link into the configured repo = %+v, want a missing path", broken)
+	}
+	if broken := lv.validateLink("a.md", linkInfo{url: "https://github.com/bordenet/genesis/blob/main/b.md", line: 1}); broken != nil {
+		t.Errorf("link into another repo = %+v, want it skipped as external", broken)
+	}
+}
@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"bufio"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule is one pattern from a .gitignore file
+type ignoreRule struct {
+	base    string // Directory of the .gitignore, slash-separated; "" for the root
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// gitIgnore applies .gitignore files the way git does, for directories that
+// are not git working trees. Rules from deeper files and later lines win.
+type gitIgnore struct {
+	fsys   fs.FS
+	rules  map[string][]ignoreRule // Directory -> rules from its .gitignore
+	loaded map[string]bool
+}
+
+// newGitIgnore creates a matcher for the tree at the root of fsys
+func newGitIgnore(fsys fs.FS) *gitIgnore {
+	return &gitIgnore{fsys: fsys, rules: make(map[string][]ignoreRule), loaded: make(map[string]bool)}
+}
+
+// load reads the .gitignore of a directory once
+func (g *gitIgnore) load(dir string) {
+	if g.loaded[dir] {
+		return
+	}
+	g.loaded[dir] = true
+
+	file, err := g.fsys.Open(path.Join(dir, ".gitignore"))
+	if err != nil {
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	base := dir
+	if base == "." {
+		base = ""
+	}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(base, scanner.Text()); ok {
+			g.rules[dir] = append(g.rules[dir], rule)
+		}
+	}
+}
+
+// parseIgnoreRule compiles one .gitignore line
+func parseIgnoreRule(base, line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	// A slash anywhere but the end anchors the pattern to the .gitignore's directory
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegexp(line)
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "^(?:.*/)?" + expr + "$"
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+// globToRegexp translates gitignore glob syntax, including **, to a regexp
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end == -1 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// match reports whether the rules of the directories above p ignore it
+func (g *gitIgnore) match(p string, isDir bool) bool {
+	ignored := false
+	dirs := []string{"."}
+	for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+
+	// Shallow .gitignore files first, so deeper rules override them
+	for i := len(dirs) - 1; i >= 0; i-- {
+		g.load(dirs[i])
+		for _, rule := range g.rules[dirs[i]] {
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			rel := p
+			if rule.base != "" {
+				rel = strings.TrimPrefix(p, rule.base+"/")
+			}
+			if rule.pattern.MatchString(rel) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// Ignored reports whether p, a slash-separated path relative to the root, is
+// ignored by itself or through an ignored parent directory
+func (g *gitIgnore) Ignored(p string, isDir bool) bool {
+	p = path.Clean(p)
+	var parents []string
+	for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+		parents = append(parents, dir)
+	}
+	for i := len(parents) - 1; i >= 0; i-- {
+		if g.match(parents[i], true) {
+			return true
+		}
+	}
+	return g.match(p, isDir)
+}
@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GraphEdge is a markdown link from one file to another
+type GraphEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"` // Repo-relative target; the unresolved path when broken
+	Line   int    `json:"line"`
+	Broken bool   `json:"broken,omitempty"`
+}
+
+// LinkGraph is the directed graph of links between markdown files. Nodes are
+// the markdown files; edges may also point at other files and directories.
+type LinkGraph struct {
+	Nodes []string
+	Edges []GraphEdge
+}
+
+// BuildLinkGraph extracts the links of every markdown file into a graph.
+// External links that don't point into this repository are left out.
+func (lv *LinkValidator) BuildLinkGraph(files []string) (*LinkGraph, error) {
+	graph := &LinkGraph{}
+	for _, file := range files {
+		graph.Nodes = append(graph.Nodes, cleanSlash(file))
+	}
+	sort.Strings(graph.Nodes)
+
+	for _, node := range graph.Nodes {
+		links, err := lv.extractLinks(filepath.FromSlash(node))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, link := range links {
+			targets := lv.linkTargets(node, link.url)
+			if len(targets) == 0 {
+				continue
+			}
+
+			edge := GraphEdge{From: node, To: targets[0], Line: link.line}
+			for _, target := range targets {
+				if lv.exists(filepath.FromSlash(target)) {
+					edge.To = target
+					break
+				}
+			}
+			edge.Broken = lv.validateLink(filepath.FromSlash(node), link) != nil
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	return graph, nil
+}
+
+// Reachable returns the markdown files reachable from the entry points by
+// following links. A link to a directory reaches its README.md. Broken links
+// whose target exists, such as those strict mode rejects, are still followed.
+func (g *LinkGraph) Reachable(entries []string) map[string]bool {
+	nodes := make(map[string]bool, len(g.Nodes))
+	for _, node := range g.Nodes {
+		nodes[node] = true
+	}
+
+	outbound := make(map[string][]string)
+	for _, edge := range g.Edges {
+		to := edge.To
+		if !nodes[to] && nodes[path.Join(to, "README.md")] {
+			to = path.Join(to, "README.md")
+		}
+		if nodes[to] {
+			outbound[edge.From] = append(outbound[edge.From], to)
+		}
+	}
+
+	reached := make(map[string]bool)
+	var queue []string
+	for _, entry := range entries {
+		entry = cleanSlash(entry)
+		if nodes[entry] && !reached[entry] {
+			reached[entry] = true
+			queue = append(queue, entry)
+		}
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range outbound[node] {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return reached
+}
+
+// LinkGraph builds the link graph of every markdown file in the repository
+func (v *Validator) LinkGraph(ctx context.Context) (*LinkGraph, error) {
+	mdFiles, err := v.linkValidator.findMarkdownFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return v.linkValidator.BuildLinkGraph(mdFiles)
+}
+
+// reachabilityExempt reports whether a doc matches an unreachableIgnore glob.
+// A glob naming a directory exempts everything beneath it. The baseline is
+// always exempt, since it is copied into new projects rather than read here.
+func (v *Validator) reachabilityExempt(doc string) bool {
+	if v.config.BaselineDir != "" && matchPathPattern(cleanSlash(v.config.BaselineDir), doc, true) {
+		return true
+	}
+	for _, pattern := range v.config.UnreachableIgnore {
+		if matchPathPattern(cleanSlash(pattern), doc, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultEntryPoints returns the documents readers and AI assistants start from
+func DefaultEntryPoints() []string {
+	return []string{"README.md", "genesis/START-HERE.md", "AGENT.md"}
+}
+
+// entryPoints returns the configured entry points that exist
+func (v *Validator) entryPoints() []string {
+	var entries []string
+	for _, entry := range v.config.EntryPoints {
+		if v.ws.Exists(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// findUnreachableDocs reports markdown files that cannot be reached by
+// following links from any configured entry point
+func (v *Validator) findUnreachableDocs(mdFiles []string) ([]Inconsistency, error) {
+	entries := v.entryPoints()
+	if len(entries) == 0 {
+		return nil, nil // Nothing to measure reachability from
+	}
+
+	graph, err := v.linkValidator.BuildLinkGraph(mdFiles)
+	if err != nil {
+		return nil, err
+	}
+	reached := graph.Reachable(entries)
+
+	var findings []Inconsistency
+	for _, node := range graph.Nodes {
+		if reached[node] || v.reachabilityExempt(node) {
+			continue
+		}
+		findings = append(findings, Inconsistency{
+			Type:        "unreachable_doc",
+			File:        node,
+			Description: "Not reachable by links from " + strings.Join(entries, ", ") + "; link it from an index or move it to _archive/",
+			Location:    node,
+		})
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,257 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// GraphOptions controls how a LinkGraph is rendered
+type GraphOptions struct {
+	Format          string // "dot", "mermaid" or "json"
+	CollapseDirs    bool   // Merge files into one node per directory
+	HighlightBroken bool   // Draw broken links and missing targets in red
+}
+
+// GraphFormats lists the supported output formats
+var GraphFormats = []string{"dot", "mermaid", "json"}
+
+// edgeGroup is every link between one pair of rendered nodes
+type edgeGroup struct {
+	from, to string
+	lines    []int
+	broken   int
+}
+
+// Write renders the graph in the requested format
+func (g *LinkGraph) Write(w io.Writer, opts GraphOptions) error {
+	switch opts.Format {
+	case "dot", "":
+		return g.writeDOT(w, opts)
+	case "mermaid":
+		return g.writeMermaid(w, opts)
+	case "json":
+		return g.writeJSON(w, opts)
+	default:
+		return fmt.Errorf("unknown graph format %q, want one of %s", opts.Format, strings.Join(GraphFormats, ", "))
+	}
+}
+
+// nodeName maps a file to its rendered node: the file itself, or its
+// directory when collapsing
+func nodeName(file string, collapse bool) string {
+	if !collapse {
+		return file
+	}
+	return path.Dir(file) + "/"
+}
+
+// renderedNodes returns the sorted nodes to draw: every markdown file plus
+// every link target, and the targets missing from the repo
+func (g *LinkGraph) renderedNodes(opts GraphOptions) (nodes []string, missing map[string]bool) {
+	seen := make(map[string]bool)
+	missing = make(map[string]bool)
+	add := func(file string) {
+		name := nodeName(file, opts.CollapseDirs)
+		if !seen[name] {
+			seen[name] = true
+			nodes = append(nodes, name)
+		}
+	}
+
+	for _, node := range g.Nodes {
+		add(node)
+	}
+	for _, edge := range g.Edges {
+		add(edge.To)
+		if edge.Broken && !opts.CollapseDirs {
+			missing[edge.To] = true
+		}
+	}
+	for _, node := range g.Nodes {
+		delete(missing, node)
+	}
+
+	sort.Strings(nodes)
+	return nodes, missing
+}
+
+// groupEdges merges links between the same pair of rendered nodes. Links
+// within one directory are dropped when collapsing.
+func (g *LinkGraph) groupEdges(opts GraphOptions) []edgeGroup {
+	index := make(map[[2]string]int)
+	var groups []edgeGroup
+
+	for _, edge := range g.Edges {
+		from, to := nodeName(edge.From, opts.CollapseDirs), nodeName(edge.To, opts.CollapseDirs)
+		if opts.CollapseDirs && from == to {
+			continue
+		}
+		key := [2]string{from, to}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, edgeGroup{from: from, to: to})
+		}
+		groups[i].lines = append(groups[i].lines, edge.Line)
+		if edge.Broken {
+			groups[i].broken++
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].from != groups[j].from {
+			return groups[i].from < groups[j].from
+		}
+		return groups[i].to < groups[j].to
+	})
+	return groups
+}
+
+// label describes the links in a group: their line numbers, or a count when
+// collapsed since lines from different files can't be told apart
+func (e edgeGroup) label(collapsed bool) string {
+	if collapsed {
+		label := fmt.Sprintf("%d links", len(e.lines))
+		if len(e.lines) == 1 {
+			label = "1 link"
+		}
+		if e.broken > 0 {
+			label += fmt.Sprintf(" (%d broken)", e.broken)
+		}
+		return label
+	}
+
+	lines := make([]string, len(e.lines))
+	for i, line := range e.lines {
+		lines[i] = fmt.Sprintf("L%d", line)
+	}
+	return strings.Join(lines, ", ")
+}
+
+// writeDOT renders the graph as Graphviz DOT
+func (g *LinkGraph) writeDOT(w io.Writer, opts GraphOptions) error {
+	nodes, missing := g.renderedNodes(opts)
+
+	var b strings.Builder
+	b.WriteString("digraph links {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+	for _, node := range nodes {
+		attrs := ""
+		if opts.HighlightBroken && missing[node] {
+			attrs = " [color=red, fontcolor=red, style=dashed]"
+		}
+		fmt.Fprintf(&b, "  %s%s;\n", dotQuote(node), attrs)
+	}
+	for _, e := range g.groupEdges(opts) {
+		attrs := "label=" + dotQuote(e.label(opts.CollapseDirs))
+		if opts.HighlightBroken && e.broken > 0 {
+			attrs += ", color=red, fontcolor=red"
+			if e.broken == len(e.lines) {
+				attrs += ", style=dashed"
+			}
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(e.from), dotQuote(e.to), attrs)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMermaid renders the graph as a Mermaid flowchart
+func (g *LinkGraph) writeMermaid(w io.Writer, opts GraphOptions) error {
+	nodes, missing := g.renderedNodes(opts)
+	ids := make(map[string]string, len(nodes))
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, node := range nodes {
+		ids[node] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node], mermaidEscape(node))
+	}
+
+	var brokenEdges, missingNodes []string
+	for i, e := range g.groupEdges(opts) {
+		fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", ids[e.from], mermaidEscape(e.label(opts.CollapseDirs)), ids[e.to])
+		if e.broken > 0 {
+			brokenEdges = append(brokenEdges, fmt.Sprint(i))
+		}
+	}
+	for _, node := range nodes {
+		if missing[node] {
+			missingNodes = append(missingNodes, ids[node])
+		}
+	}
+
+	if opts.HighlightBroken {
+		if len(brokenEdges) > 0 {
+			fmt.Fprintf(&b, "  linkStyle %s stroke:#d00,color:#d00\n", strings.Join(brokenEdges, ","))
+		}
+		if len(missingNodes) > 0 {
+			b.WriteString("  classDef missing stroke:#d00,color:#d00,stroke-dasharray:4\n")
+			fmt.Fprintf(&b, "  class %s missing\n", strings.Join(missingNodes, ","))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// jsonGraph is the JSON form of a LinkGraph: nodes and adjacency lists
+type jsonGraph struct {
+	Nodes     []string              `json:"nodes"`
+	Missing   []string              `json:"missing,omitempty"`
+	Adjacency map[string][]jsonEdge `json:"adjacency"`
+}
+
+// jsonEdge is one link in an adjacency list
+type jsonEdge struct {
+	To     string `json:"to"`
+	Source string `json:"source,omitempty"` // Linking file, when nodes are collapsed directories
+	Line   int    `json:"line"`
+	Broken bool   `json:"broken,omitempty"`
+}
+
+// writeJSON renders the graph as JSON adjacency lists. Every link is listed
+// individually so line numbers survive collapsing.
+func (g *LinkGraph) writeJSON(w io.Writer, opts GraphOptions) error {
+	nodes, missing := g.renderedNodes(opts)
+	out := jsonGraph{Nodes: nodes, Adjacency: make(map[string][]jsonEdge)}
+	for _, node := range nodes {
+		if missing[node] {
+			out.Missing = append(out.Missing, node)
+		}
+	}
+
+	for _, edge := range g.Edges {
+		from, to := nodeName(edge.From, opts.CollapseDirs), nodeName(edge.To, opts.CollapseDirs)
+		if opts.CollapseDirs && from == to {
+			continue
+		}
+		e := jsonEdge{To: to, Line: edge.Line, Broken: edge.Broken}
+		if opts.CollapseDirs {
+			e.Source = edge.From
+		}
+		out.Adjacency[from] = append(out.Adjacency[from], e)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// dotQuote quotes a string as a DOT identifier
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// mermaidEscape escapes a string for a quoted Mermaid label
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
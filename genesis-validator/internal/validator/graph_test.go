@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestBuildLinkGraph(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "README.md", "# Repo\n\n[Guide](docs/guide.md)\n[Site](https://example.com)\n[Gone](docs/gone.md)\n")
+	writeTestFile(t, tmpDir, "docs/guide.md", "# Guide\n\n[Home](../README.md#repo)\n")
+	chdir(t, tmpDir)
+
+	graph, err := NewLinkValidator(DefaultConfig()).BuildLinkGraph([]string{"README.md", "docs/guide.md"})
+	if err != nil {
+		t.Fatalf("BuildLinkGraph() error = %v", err)
+	}
+
+	want := []GraphEdge{
+		{From: "README.md", To: "docs/guide.md", Line: 3},
+		{From: "README.md", To: "docs/gone.md", Line: 5, Broken: true},
+		{From: "docs/guide.md", To: "README.md", Line: 3},
+	}
+	if !reflect.DeepEqual(graph.Edges, want) {
+		t.Errorf("Edges = %+v, want %+v", graph.Edges, want)
+	}
+}
+
+func TestLinkGraph_Reachable(t *testing.T) {
+	graph := &LinkGraph{
+		Nodes: []string{"README.md", "a.md", "b.md", "dir/README.md", "island.md", "orphan.md", "strict.md"},
+		Edges: []GraphEdge{
+			{From: "README.md", To: "a.md"},
+			{From: "a.md", To: "b.md"},
+			{From: "b.md", To: "dir"},
+			{From: "b.md", To: "docs/orphan.md", Broken: true}, // Target missing
+			{From: "b.md", To: "strict.md", Broken: true},      // Target exists, rejected by strict mode
+			{From: "island.md", To: "README.md"},
+		},
+	}
+
+	reached := graph.Reachable([]string{"README.md"})
+	var got []string
+	for node := range reached {
+		got = append(got, node)
+	}
+	sort.Strings(got)
+
+	want := []string{"README.md", "a.md", "b.md", "dir/README.md", "strict.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reachable() = %v, want %v", got, want)
+	}
+}
+
+func TestValidate_UnreachableDocs(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	config.EntryPoints = []string{"README.md"}
+	config.UnreachableIgnore = []string{"notes"}
+	config.BaselineDir = tmpDir + "/genesis/examples/hello-world"
+	writeTestFile(t, tmpDir, "README.md", "# Repo\n\n[Docs](docs/index.md)\n")
+	writeTestFile(t, tmpDir, "docs/index.md", "# Docs\n\n[Start](../genesis/START-HERE.md)\n[Checklist](../genesis/00-AI-MUST-READ-FIRST.md)\n")
+	writeTestFile(t, tmpDir, "docs/forgotten.md", "# Forgotten\n\n[Docs](index.md)\n")
+	writeTestFile(t, tmpDir, "notes/scratch.md", "# Scratch\n")
+	writeTestFile(t, tmpDir, "genesis/examples/hello-world/README.md", "# Hello World\n")
+	writeTestFile(t, tmpDir, "genesis/examples/hello-world/docs/usage.md", "# Usage\n")
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var unreachable []string
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "unreachable_doc" {
+			unreachable = append(unreachable, inc.File)
+		}
+	}
+	if !reflect.DeepEqual(unreachable, []string{"docs/forgotten.md"}) {
+		t.Errorf("unreachable docs = %v, want [docs/forgotten.md] (notes/ ignored, baseline exempt)", unreachable)
+	}
+}
+
+func TestValidate_UnreachableDocsStrict(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	config.Strict = true
+	config.EntryPoints = []string{"README.md"}
+	writeTestFile(t, tmpDir, "README.md", "# Repo\n\n[Docs](docs/index.md)\n")
+	// Resolves only from the repository root, which strict mode rejects
+	writeTestFile(t, tmpDir, "docs/index.md", "# Docs\n\n[Guide](docs/guide.md)\n")
+	writeTestFile(t, tmpDir, "docs/guide.md", "# Guide\n\n[Start](../genesis/START-HERE.md)\n[Checklist](../genesis/00-AI-MUST-READ-FIRST.md)\n")
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var broken, unreachable []string
+	for _, inc := range result.Inconsistencies {
+		switch inc.Type {
+		case "broken_link":
+			broken = append(broken, inc.File)
+		case "unreachable_doc":
+			unreachable = append(unreachable, inc.File)
+		}
+	}
+	if !reflect.DeepEqual(broken, []string{"docs/index.md"}) {
+		t.Errorf("broken links in = %v, want [docs/index.md]", broken)
+	}
+	if len(unreachable) != 0 {
+		t.Errorf("unreachable docs = %v, want none behind a link strict mode rejects", unreachable)
+	}
+}
+
+func sampleGraph() *LinkGraph {
+	return &LinkGraph{
+		Nodes: []string{"README.md", "docs/a.md", "docs/b.md"},
+		Edges: []GraphEdge{
+			{From: "README.md", To: "docs/a.md", Line: 3},
+			{From: "README.md", To: "docs/a.md", Line: 9},
+			{From: "docs/a.md", To: "docs/b.md", Line: 4},
+			{From: "docs/b.md", To: "docs/gone.md", Line: 7, Broken: true},
+		},
+	}
+}
+
+func TestLinkGraph_WriteDOT(t *testing.T) {
+	var out strings.Builder
+	if err := sampleGraph().Write(&out, GraphOptions{Format: "dot", HighlightBroken: true}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`"README.md" -> "docs/a.md" [label="L3, L9"];`,
+		`"docs/a.md" -> "docs/b.md" [label="L4"];`,
+		`"docs/b.md" -> "docs/gone.md" [label="L7", color=red, fontcolor=red, style=dashed];`,
+		`"docs/gone.md" [color=red, fontcolor=red, style=dashed];`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestLinkGraph_WriteMermaidCollapsed(t *testing.T) {
+	var out strings.Builder
+	if err := sampleGraph().Write(&out, GraphOptions{Format: "mermaid", CollapseDirs: true, HighlightBroken: true}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := "flowchart LR\n" +
+		"  n0[\"./\"]\n" +
+		"  n1[\"docs/\"]\n" +
+		"  n0 -->|\"2 links\"| n1\n"
+	if out.String() != want {
+		t.Errorf("Mermaid output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestLinkGraph_WriteJSON(t *testing.T) {
+	var out strings.Builder
+	if err := sampleGraph().Write(&out, GraphOptions{Format: "json"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var got jsonGraph
+	if err := json.Unmarshal([]byte(out.String()), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got.Missing, []string{"docs/gone.md"}) {
+		t.Errorf("Missing = %v, want [docs/gone.md]", got.Missing)
+	}
+	wantReadme := []jsonEdge{{To: "docs/a.md", Line: 3}, {To: "docs/a.md", Line: 9}}
+	if !reflect.DeepEqual(got.Adjacency["README.md"], wantReadme) {
+		t.Errorf("Adjacency[README.md] = %+v, want %+v", got.Adjacency["README.md"], wantReadme)
+	}
+	if edges := got.Adjacency["docs/b.md"]; len(edges) != 1 || !edges[0].Broken {
+		t.Errorf("Adjacency[docs/b.md] = %+v, want one broken edge", edges)
+	}
+}
+
+func TestLinkGraph_WriteUnknownFormat(t *testing.T) {
+	if err := sampleGraph().Write(&strings.Builder{}, GraphOptions{Format: "png"}); err == nil {
+		t.Error("Write() with unknown format should fail")
+	}
+}
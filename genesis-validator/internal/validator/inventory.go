@@ -0,0 +1,274 @@
+package validator
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// cpCommandPattern matches shell copy commands: cp [-flags...] <src> <dest>
+var cpCommandPattern = regexp.MustCompile(`(?:^|[;&|]\s*)cp\s+((?:-[a-zA-Z]+\s+)*)(\S+)\s+(\S+)`)
+
+// editHeadingPattern matches headings of sections that tell the AI which files to edit
+var editHeadingPattern = regexp.MustCompile(`(?i)^#{1,6}\s.*\b(customi[sz]e|edit|modify)`)
+
+// tablePathPattern matches a backticked path in the first column of a table row
+var tablePathPattern = regexp.MustCompile("^\\s*\\|\\s*`([^`\\s]+)`\\s*\\|")
+
+// copyInstruction is a cp command whose source lies in the baseline directory
+type copyInstruction struct {
+	source    string // Path relative to the baseline directory; "" for the directory itself
+	recursive bool
+	ref       Reference
+}
+
+// Inventory checks that the hello-world baseline and the step instructions
+// that copy from it agree
+type Inventory struct {
+	config *Config
+	parser *Parser
+	ws     *workspace
+}
+
+// NewInventory creates a new Inventory
+func NewInventory(config *Config) *Inventory {
+	ws, config := openWorkspace(config, nil)
+	return &Inventory{config: config, parser: &Parser{config: config, ws: ws}, ws: ws}
+}
+
+// BaselineFiles lists every file in the baseline directory, relative to it
+func (inv *Inventory) BaselineFiles(ctx context.Context) ([]string, error) {
+	root := inv.config.BaselineDir
+	var files []string
+
+	err := inv.ws.Walk(ctx, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && (d.Name() == "node_modules" || d.Name() == ".git" || d.Name() == "coverage") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// Check compares the baseline against the copy and edit instructions in the
+// reference documents. Orphaned files exist in the baseline but are neither
+// copied nor listed as not copied; missing files are copied or edited by the
+// instructions but don't exist in the baseline. Paths are repo-relative.
+func (inv *Inventory) Check(ctx context.Context) (orphaned []string, missing []Reference, err error) {
+	files, err := inv.BaselineFiles(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	docs, err := inv.parser.ReferenceDocs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var copies []copyInstruction
+	var edits []Reference
+	for _, doc := range docs {
+		c, e, err := inv.parseInstructions(doc)
+		if err != nil {
+			return nil, nil, err
+		}
+		copies = append(copies, c...)
+		edits = append(edits, e...)
+	}
+
+	baseline := cleanSlash(inv.config.BaselineDir)
+
+	for _, file := range files {
+		if inv.notCopied(file) {
+			continue
+		}
+		covered := false
+		for _, c := range copies {
+			if copyCovers(c, file) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			orphaned = append(orphaned, baseline+"/"+file)
+		}
+	}
+
+	for _, c := range copies {
+		if c.source != "" && !matchesAny(files, c.source, true) {
+			missing = append(missing, c.ref)
+		}
+	}
+	for _, e := range edits {
+		if !matchesAny(files, e.Path, true) {
+			missing = append(missing, e)
+		}
+	}
+
+	for i := range missing {
+		missing[i].Path = baseline + "/" + strings.TrimSuffix(missing[i].Path, "/")
+	}
+
+	return orphaned, missing, nil
+}
+
+// parseInstructions extracts copy commands that read from the baseline and
+// files listed in "customize"/"edit" tables. Code blocks are included since
+// that is where copy commands live; commented-out commands are ignored.
+func (inv *Inventory) parseInstructions(doc string) ([]copyInstruction, []Reference, error) {
+	file, err := inv.ws.Open(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	prefix := inv.copyPrefix()
+	display := displayPath(doc)
+
+	var copies []copyInstruction
+	var edits []Reference
+	inCodeBlock := false
+	inEditSection := false
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if codeFencePattern.MatchString(line) {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+
+		if inCodeBlock {
+			if strings.HasPrefix(trimmed, "#") {
+				continue // Shell comment, e.g. "# WRONG: cp ..."
+			}
+			for _, match := range cpCommandPattern.FindAllStringSubmatch(line, -1) {
+				source := strings.Trim(match[2], `"'`)
+				rel, ok := baselineRelative(prefix, source)
+				if !ok {
+					continue
+				}
+				copies = append(copies, copyInstruction{
+					source:    rel,
+					recursive: strings.ContainsAny(match[1], "rRa"),
+					ref:       Reference{Path: rel, Doc: display, Line: lineNum},
+				})
+			}
+			continue
+		}
+
+		if headingPattern.MatchString(line) {
+			inEditSection = editHeadingPattern.MatchString(line)
+			continue
+		}
+		if inEditSection {
+			if match := tablePathPattern.FindStringSubmatch(line); match != nil && strings.Contains(match[1], "/") {
+				edits = append(edits, Reference{Path: match[1], Doc: display, Line: lineNum})
+			}
+		}
+	}
+
+	return copies, edits, scanner.Err()
+}
+
+// copyPrefix returns the baseline directory as the steps spell it: relative
+// to a project where genesis is checked out as ./genesis
+func (inv *Inventory) copyPrefix() string {
+	rel, err := filepath.Rel(inv.config.GenesisRoot, inv.config.BaselineDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return cleanSlash(inv.config.BaselineDir)
+	}
+	return "genesis/" + filepath.ToSlash(rel)
+}
+
+// baselineRelative returns source relative to the baseline directory prefix
+func baselineRelative(prefix, source string) (string, bool) {
+	cleaned := path.Clean(source)
+	if cleaned == prefix {
+		return "", true
+	}
+	if !strings.HasPrefix(cleaned, prefix+"/") {
+		return "", false
+	}
+	rel := strings.TrimPrefix(cleaned, prefix+"/")
+	if rel == "." {
+		rel = ""
+	}
+	return rel, true
+}
+
+// notCopied reports whether a baseline file is explicitly excluded from copying
+func (inv *Inventory) notCopied(file string) bool {
+	for _, pattern := range inv.config.NotCopied {
+		if matchPathPattern(pattern, file, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// copyCovers reports whether a copy instruction copies the baseline file
+func copyCovers(c copyInstruction, file string) bool {
+	if c.source == "" {
+		return c.recursive || !strings.Contains(file, "/")
+	}
+	return matchPathPattern(c.source, file, c.recursive)
+}
+
+// matchPathPattern reports whether a slash-separated shell pattern matches
+// file. With prefix, a match on a leading directory also matches everything
+// beneath it. As in the shell, wildcards don't match names starting with a dot.
+func matchPathPattern(pattern, file string, prefix bool) bool {
+	patternParts := strings.Split(strings.TrimSuffix(pattern, "/"), "/")
+	fileParts := strings.Split(file, "/")
+
+	if len(fileParts) < len(patternParts) || (!prefix && len(fileParts) != len(patternParts)) {
+		return false
+	}
+
+	for i, part := range patternParts {
+		name := fileParts[i]
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(part, ".") {
+			return false
+		}
+		if matched, err := path.Match(part, name); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether pattern matches at least one file
+func matchesAny(files []string, pattern string, prefix bool) bool {
+	for _, file := range files {
+		if matchPathPattern(pattern, file, prefix) {
+			return true
+		}
+	}
+	return false
+}
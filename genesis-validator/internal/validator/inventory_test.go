@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMatchPathPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		prefix  bool
+		want    bool
+	}{
+		{"*", "package.json", false, true},
+		{"*", "assistant/index.html", false, false},
+		{"*", "assistant/index.html", true, true},
+		{"*", ".gitignore", true, false},
+		{".*", ".gitignore", false, true},
+		{"assistant/*.md", "assistant/README.md", false, true},
+		{"assistant/*.md", "validator/README.md", false, false},
+		{"assistant", "assistant/js/app.js", true, true},
+		{"assistant/js/app.js", "assistant", true, false},
+	}
+
+	for _, tt := range tests {
+		if got := matchPathPattern(tt.pattern, tt.file, tt.prefix); got != tt.want {
+			t.Errorf("matchPathPattern(%q, %q, %v) = %v, want %v", tt.pattern, tt.file, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestInventory_Check(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, file := range []string{
+		"genesis/examples/hello-world/package.json",
+		"genesis/examples/hello-world/assistant/index.html",
+		"genesis/examples/hello-world/.gitignore",
+		"genesis/examples/hello-world/.env.example",
+		"genesis/examples/hello-world/docs/notes.md",
+	} {
+		writeTestFile(t, tmpDir, file, "content")
+	}
+	writeTestFile(t, tmpDir, "genesis/steps/03-copy.md", "# Copy\n"+
+		"\n"+
+		"```bash\n"+
+		"cp -r genesis/examples/hello-world/* .\n"+ // 4
+		"cp genesis/examples/hello-world/.gitignore .\n"+ // 5
+		"# WRONG: cp -r genesis/examples/hello-world/templates/* .\n"+ // 6
+		"cp genesis/examples/hello-world/js/core/app.js js/\n"+ // 7
+		"```\n"+
+		"\n"+
+		"## Customize Files\n"+
+		"\n"+
+		"| File | Why |\n"+
+		"|------|-----|\n"+
+		"| `assistant/index.html` | Layout |\n"+ // 14
+		"| `assistant/prompts/*.md` | Prompts |\n") // 15
+	chdir(t, tmpDir)
+
+	config := &Config{
+		GenesisRoot:   "genesis",
+		BaselineDir:   "genesis/examples/hello-world",
+		NotCopied:     []string{"docs"},
+		ReferenceDocs: []string{"genesis/steps/*.md"},
+	}
+
+	orphaned, missing, err := NewInventory(config).Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	wantOrphaned := []string{"genesis/examples/hello-world/.env.example"}
+	if !reflect.DeepEqual(orphaned, wantOrphaned) {
+		t.Errorf("orphaned = %v, want %v", orphaned, wantOrphaned)
+	}
+
+	wantMissing := []Reference{
+		{Path: "genesis/examples/hello-world/js/core/app.js", Doc: "genesis/steps/03-copy.md", Line: 7},
+		{Path: "genesis/examples/hello-world/assistant/prompts/*.md", Doc: "genesis/steps/03-copy.md", Line: 15},
+	}
+	if !reflect.DeepEqual(missing, wantMissing) {
+		t.Errorf("missing = %+v, want %+v", missing, wantMissing)
+	}
+}
+
+func TestValidate_BaselineInventory(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "genesis/examples/hello-world/index.html", "<html></html>")
+	writeTestFile(t, tmpDir, "genesis/examples/hello-world/.nvmrc", "20")
+	writeTestFile(t, tmpDir, "genesis/START-HERE.md", "# Start\n\n```bash\ncp -r genesis/examples/hello-world/* .\n```\n")
+	writeTestFile(t, tmpDir, "genesis/CHECKLIST.md", "# Checklist\n")
+	chdir(t, tmpDir)
+
+	config := DefaultConfig()
+	config.GeneratePrompt = false
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("Validate() errors = %v", result.Errors)
+	}
+
+	want := []string{"genesis/examples/hello-world/.nvmrc"}
+	if !reflect.DeepEqual(result.OrphanedFiles, want) {
+		t.Errorf("OrphanedFiles = %v, want %v", result.OrphanedFiles, want)
+	}
+}
+
+func TestValidate_NoTemplatesOrBaseline(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "genesis/START-HERE.md", "# Start\n")
+	writeTestFile(t, tmpDir, "genesis/CHECKLIST.md", "# Checklist\n")
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(DefaultConfig()).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("expected an error when neither templates nor baseline exist, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"bytes"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IndexedLink is a link recorded in a LinkIndex together with its source file
+type IndexedLink struct {
+	SourceFile string
+	link       linkInfo
+}
+
+// LinkIndex maps link targets to the links that point at them
+type LinkIndex struct {
+	inbound map[string][]IndexedLink
+}
+
+// BuildLinkIndex extracts links from every file and indexes them by target path.
+// Files that cannot be read are skipped, matching ValidateAllLinks.
+func (lv *LinkValidator) BuildLinkIndex(files []string, read func(path string) ([]byte, error)) *LinkIndex {
+	idx := &LinkIndex{inbound: make(map[string][]IndexedLink)}
+
+	for _, file := range files {
+		content, err := read(file)
+		if err != nil {
+			continue
+		}
+		links, err := extractLinksFrom(bytes.NewReader(content))
+		if err != nil {
+			continue
+		}
+		for _, link := range links {
+			for _, target := range lv.linkTargets(file, link.url) {
+				idx.inbound[target] = append(idx.inbound[target], IndexedLink{SourceFile: file, link: link})
+			}
+		}
+	}
+
+	return idx
+}
+
+// Inbound returns the links that resolve to target, ordered by source and line
+func (idx *LinkIndex) Inbound(target string) []IndexedLink {
+	links := append([]IndexedLink(nil), idx.inbound[cleanSlash(target)]...)
+	sort.SliceStable(links, func(i, j int) bool {
+		if links[i].SourceFile != links[j].SourceFile {
+			return links[i].SourceFile < links[j].SourceFile
+		}
+		return links[i].link.line < links[j].link.line
+	})
+	return links
+}
+
+// linkTargets returns every repo-relative path a link may resolve to.
+// Relative links are indexed both from the source directory and from the
+// repo root, mirroring the fallback in validateRelativePath. GitHub URLs
+// only point into the working tree when they name the default branch.
+func (lv *LinkValidator) linkTargets(sourceFile, url string) []string {
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		if gh, ok := lv.parseGitHubURL(url); ok {
+			if localPath, ok := lv.defaultBranchPath(gh); ok {
+				return []string{cleanSlash(localPath)}
+			}
+		}
+		return nil
+	}
+
+	if strings.HasPrefix(url, "mailto:") || strings.HasPrefix(url, "#") || strings.HasPrefix(url, "javascript:") {
+		return nil
+	}
+
+	target := stripAnchor(url)
+	if target == "" {
+		return nil
+	}
+
+	relative := cleanSlash(path.Join(path.Dir(filepath.ToSlash(sourceFile)), target))
+	fromRoot := cleanSlash(target)
+	if relative == fromRoot {
+		return []string{relative}
+	}
+	return []string{relative, fromRoot}
+}
+
+// stripAnchor removes a trailing #fragment from a link target
+func stripAnchor(url string) string {
+	if idx := strings.Index(url, "#"); idx != -1 {
+		return url[:idx]
+	}
+	return url
+}
+
+// cleanSlash normalizes a path to a clean, slash-separated form
+func cleanSlash(p string) string {
+	return path.Clean(filepath.ToSlash(p))
+}
@@ -1,10 +1,9 @@
 package validator
 
 import (
-	"bufio"
-	"os"
+	"context"
+	"io"
 	"path/filepath"
-	"regexp"
 	"strings"
 )
 
@@ -15,69 +14,120 @@ type BrokenLink struct {
 	LinkText   string // The display text of the link
 	LinkURL    string // The URL/path that is broken
 	Reason     string // Why it's broken (file not found, etc.)
+	Fix        *Fix   // Mechanical edit that repairs the link, nil when there is none
+	rule       string // Finding type when it is not broken_link
+}
+
+// findingType returns the finding type a broken link is reported as
+func (l BrokenLink) findingType() string {
+	if l.rule != "" {
+		return l.rule
+	}
+	return "broken_link"
 }
 
 // LinkValidator validates markdown links in the repository
 type LinkValidator struct {
 	config *Config
+	ws     *workspace
+	exists func(path string) bool            // Reports whether a link target exists
+	read   func(path string) ([]byte, error) // Reads a link target, for line anchors
+	refs   *gitHubRefs                       // Resolves GitHub URLs on refs other than the default branch
+	cache  fileCache                         // Files found by discovery
 }
 
 // NewLinkValidator creates a new LinkValidator
 func NewLinkValidator(config *Config) *LinkValidator {
-	return &LinkValidator{config: config}
+	ws, config := openWorkspace(config, nil)
+	return newLinkValidator(config, ws)
 }
 
-// ValidateAllLinks scans all markdown files and validates internal links
-func (lv *LinkValidator) ValidateAllLinks() ([]BrokenLink, error) {
-	var brokenLinks []BrokenLink
+// newLinkValidator creates a LinkValidator for an opened workspace
+func newLinkValidator(config *Config, ws *workspace) *LinkValidator {
+	lv := &LinkValidator{config: config, ws: ws, read: ws.ReadFile, refs: newGitHubRefs(ws.Git())}
+	lv.exists = lv.inRepo
+	return lv
+}
 
+// ValidateAllLinks scans all markdown files and validates internal links
+func (lv *LinkValidator) ValidateAllLinks(ctx context.Context) ([]BrokenLink, error) {
 	// Find all markdown files
-	mdFiles, err := lv.findMarkdownFiles()
+	mdFiles, err := lv.findMarkdownFiles(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return lv.validateFiles(ctx, mdFiles)
+}
 
+// validateFiles validates the internal links of the given markdown files,
+// stopping with the context's error once ctx is done
+func (lv *LinkValidator) validateFiles(ctx context.Context, mdFiles []string) ([]BrokenLink, error) {
+	var brokenLinks []BrokenLink
 	for _, mdFile := range mdFiles {
-		links, err := lv.extractLinks(mdFile)
+		if err := ctx.Err(); err != nil {
+			return brokenLinks, err
+		}
+		broken, err := lv.ValidateFileLinks(mdFile)
 		if err != nil {
 			continue // Skip files we can't read
 		}
-
-		for _, link := range links {
-			if broken := lv.validateLink(mdFile, link); broken != nil {
-				brokenLinks = append(brokenLinks, *broken)
-			}
-		}
+		brokenLinks = append(brokenLinks, broken...)
 	}
 
 	return brokenLinks, nil
 }
 
-// findMarkdownFiles finds all .md files in the repository
-func (lv *LinkValidator) findMarkdownFiles() ([]string, error) {
-	var files []string
+// ValidateFileLinks validates the links in a single markdown file
+func (lv *LinkValidator) ValidateFileLinks(mdFile string) ([]BrokenLink, error) {
+	links, err := lv.extractLinks(mdFile)
+	if err != nil {
+		return nil, err
+	}
 
-	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip errors
+	var brokenLinks []BrokenLink
+	for _, link := range links {
+		if broken := lv.validateLink(mdFile, link); broken != nil {
+			brokenLinks = append(brokenLinks, *broken)
 		}
+	}
+
+	return brokenLinks, nil
+}
 
+// findMarkdownFiles finds all .md files in the repository, rediscovering
+// the repository's files so changes since the last call are seen
+func (lv *LinkValidator) findMarkdownFiles(ctx context.Context) ([]string, error) {
+	lv.refreshFiles()
+	repo, err := lv.repoFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, file := range repo.files {
 		// Skip node_modules, .git, and other common excludes
-		if info.IsDir() {
-			name := info.Name()
-			if name == "node_modules" || name == ".git" || name == "_archive" || name == "coverage" {
-				return filepath.SkipDir
-			}
-			return nil
+		if strings.HasSuffix(file, ".md") && !isExcludedPath(file) {
+			files = append(files, filepath.FromSlash(file))
 		}
+	}
 
-		if strings.HasSuffix(path, ".md") {
-			files = append(files, path)
-		}
-		return nil
-	})
+	return files, nil
+}
 
-	return files, err
+// isExcludedDir reports whether a directory is skipped during markdown discovery
+func isExcludedDir(name string) bool {
+	return name == "node_modules" || name == ".git" || name == "_archive" || name == "coverage"
+}
+
+// isExcludedPath reports whether any directory component of path is excluded
+func isExcludedPath(path string) bool {
+	parts := strings.Split(filepath.ToSlash(filepath.Dir(path)), "/")
+	for _, part := range parts {
+		if isExcludedDir(part) {
+			return true
+		}
+	}
+	return false
 }
 
 // linkInfo holds extracted link information
@@ -85,59 +135,44 @@ type linkInfo struct {
 	text string
 	url  string
 	line int
+	col  int // Byte offset of the link within the line
 }
 
 // extractLinks extracts all markdown links from a file
 func (lv *LinkValidator) extractLinks(filePath string) ([]linkInfo, error) {
-	file, err := os.Open(filePath)
+	file, err := lv.ws.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = file.Close() }()
 
+	return extractLinksFrom(file)
+}
+
+// extractLinksFrom extracts all markdown links from markdown content
+func extractLinksFrom(r io.Reader) ([]linkInfo, error) {
 	var links []linkInfo
-	scanner := bufio.NewScanner(file)
-	lineNum := 0
-	inCodeBlock := false
-
-	// Pattern for markdown links: [text](url)
-	linkPattern := regexp.MustCompile(`\[([^\]]*)\]\(([^)]+)\)`)
-	// Pattern for fenced code block start/end (``` or ~~~)
-	codeBlockPattern := regexp.MustCompile("^\\s*(`{3,}|~{3,})")
-	// Pattern to strip inline code before extracting links
-	inlineCodePattern := regexp.MustCompile("`[^`]+`")
-
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
-
-		// Track code block state
-		if codeBlockPattern.MatchString(line) {
-			inCodeBlock = !inCodeBlock
-			continue
-		}
 
-		// Skip links inside code blocks
-		if inCodeBlock {
-			continue
-		}
+	// Links inside fenced code blocks are skipped by scanMarkdownLines
+	err := scanMarkdownLines(r, func(lineNum int, line string) {
+		// Mask inline code before extracting links to avoid false positives
+		prose := maskInlineCode(line)
 
-		// Strip inline code before extracting links to avoid false positives
-		lineWithoutInlineCode := inlineCodePattern.ReplaceAllString(line, "")
-
-		matches := linkPattern.FindAllStringSubmatch(lineWithoutInlineCode, -1)
-		for _, match := range matches {
-			if len(match) >= 3 {
-				links = append(links, linkInfo{
-					text: match[1],
-					url:  match[2],
-					line: lineNum,
-				})
+		for _, loc := range markdownLinkPattern.FindAllStringSubmatchIndex(prose, -1) {
+			url := prose[loc[4]:loc[5]]
+			if strings.TrimSpace(url) == "" {
+				continue // The whole target was inline code
 			}
+			links = append(links, linkInfo{
+				text: line[loc[2]:loc[3]],
+				url:  url,
+				line: lineNum,
+				col:  loc[0],
+			})
 		}
-	}
+	})
 
-	return links, scanner.Err()
+	return links, err
 }
 
 // validateLink checks if a link is valid, returns BrokenLink if broken
@@ -147,8 +182,7 @@ func (lv *LinkValidator) validateLink(sourceFile string, link linkInfo) *BrokenL
 	// Skip external URLs (we only validate internal links)
 	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
 		// Check GitHub URLs pointing to this repo
-		if strings.Contains(url, "github.com/bordenet/genesis/blob/main/") ||
-			strings.Contains(url, "github.com/bordenet/genesis/tree/main/") {
+		if lv.isRepoGitHubURL(url) {
 			return lv.validateGitHubLink(sourceFile, link)
 		}
 		return nil // Skip other external URLs
@@ -163,83 +197,103 @@ func (lv *LinkValidator) validateLink(sourceFile string, link linkInfo) *BrokenL
 	return lv.validateRelativePath(sourceFile, link)
 }
 
-// validateGitHubLink validates a GitHub URL pointing to this repo
-func (lv *LinkValidator) validateGitHubLink(sourceFile string, link linkInfo) *BrokenLink {
+// validateRelativePath validates a relative file path
+func (lv *LinkValidator) validateRelativePath(sourceFile string, link linkInfo) *BrokenLink {
 	url := link.url
 
-	// Extract the path from GitHub URL
-	var localPath string
-	if strings.Contains(url, "/blob/main/") {
-		parts := strings.SplitN(url, "/blob/main/", 2)
-		if len(parts) == 2 {
-			localPath = parts[1]
-		}
-	} else if strings.Contains(url, "/tree/main/") {
-		parts := strings.SplitN(url, "/tree/main/", 2)
-		if len(parts) == 2 {
-			localPath = parts[1]
-		}
+	// Remove anchor from path
+	if idx := strings.Index(url, "#"); idx != -1 {
+		url = url[:idx]
 	}
 
-	if localPath == "" {
+	// Skip empty paths (anchor-only after stripping)
+	if url == "" {
 		return nil
 	}
 
-	// Remove anchor from path
-	if idx := strings.Index(localPath, "#"); idx != -1 {
-		localPath = localPath[:idx]
-	}
-
-	// Remove trailing characters that might have been captured
-	localPath = strings.TrimRight(localPath, `">`)
-
-	// Check if file/directory exists
-	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+	resolved, ok := lv.resolveRelative(sourceFile, url)
+	if !ok {
 		return &BrokenLink{
 			SourceFile: sourceFile,
 			Line:       link.line,
 			LinkText:   link.text,
-			LinkURL:    url,
-			Reason:     "GitHub URL points to non-existent path: " + localPath,
+			LinkURL:    link.url,
+			Reason:     "Relative path not found: " + url,
+			Fix:        lv.suggestFix(sourceFile, link),
+		}
+	}
+
+	if lv.config.Strict {
+		if reason := strictReason(sourceFile, link.url, resolved); reason != "" {
+			var fix *Fix
+			if rel := relativeLink(sourceFile, resolved); !escapesRoot(resolved) {
+				rel += strings.TrimPrefix(link.url, url)
+				fix = linkFix(sourceFile, link, rel, "Change link to "+rel)
+			}
+			return &BrokenLink{
+				SourceFile: sourceFile,
+				Line:       link.line,
+				LinkText:   link.text,
+				LinkURL:    link.url,
+				Reason:     reason,
+				Fix:        fix,
+			}
 		}
 	}
 
 	return nil
 }
 
-// validateRelativePath validates a relative file path
-func (lv *LinkValidator) validateRelativePath(sourceFile string, link linkInfo) *BrokenLink {
-	url := link.url
+// suggestFix proposes a new target for a link to a missing file, from the
+// discovered files
+func (lv *LinkValidator) suggestFix(sourceFile string, link linkInfo) *Fix {
+	files, err := lv.repoFiles(context.Background())
+	if err != nil {
+		return nil
+	}
+	return suggestLinkFix(sourceFile, link, files.files)
+}
 
-	// Remove anchor from path
-	if idx := strings.Index(url, "#"); idx != -1 {
-		url = url[:idx]
+// strictReason explains why a link that resolves locally is still wrong in
+// strict mode, or returns "". GitHub resolves links only relative to the
+// source file and never outside the repository.
+func strictReason(sourceFile, url, resolved string) string {
+	target, anchor := url, ""
+	if idx := strings.Index(target, "#"); idx != -1 {
+		target, anchor = target[:idx], target[idx:]
 	}
 
-	// Skip empty paths (anchor-only after stripping)
-	if url == "" {
-		return nil
+	relative := filepath.Join(filepath.Dir(sourceFile), target)
+	if escapesRoot(relative) {
+		return "Link escapes the repository root: " + target
+	}
+	if resolved != relative {
+		return "Resolves only from the repository root, which GitHub renders as 404; use " + relativeLink(sourceFile, resolved) + anchor
 	}
+	return ""
+}
 
-	// Get the directory of the source file
-	sourceDir := filepath.Dir(sourceFile)
+// escapesRoot reports whether a path relative to the repository root points
+// outside it
+func escapesRoot(p string) bool {
+	p = filepath.ToSlash(filepath.Clean(p))
+	return p == ".." || strings.HasPrefix(p, "../")
+}
 
-	// Resolve the relative path
-	targetPath := filepath.Join(sourceDir, url)
+// resolveRelative resolves a relative link path against the source file's
+// directory, falling back to the repo root. It returns the resolved path and
+// whether it exists.
+func (lv *LinkValidator) resolveRelative(sourceFile, url string) (string, bool) {
+	// Resolve the relative path from the directory of the source file
+	targetPath := filepath.Join(filepath.Dir(sourceFile), url)
+	if lv.exists(targetPath) {
+		return targetPath, true
+	}
 
 	// Also try from repo root
-	if _, err := os.Stat(targetPath); os.IsNotExist(err) {
-		// Try from repo root
-		if _, err := os.Stat(url); os.IsNotExist(err) {
-			return &BrokenLink{
-				SourceFile: sourceFile,
-				Line:       link.line,
-				LinkText:   link.text,
-				LinkURL:    link.url,
-				Reason:     "Relative path not found: " + url,
-			}
-		}
+	if lv.exists(url) {
+		return filepath.Clean(url), true
 	}
 
-	return nil
+	return targetPath, false
 }
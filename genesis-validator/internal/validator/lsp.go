@@ -0,0 +1,726 @@
+package validator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LSP diagnostic severities
+const (
+	lspSeverityError   = 1
+	lspSeverityWarning = 2
+)
+
+// lspCompletionKindFile is the LSP CompletionItemKind for files
+const lspCompletionKindFile = 17
+
+// lspCompletionKindReference is the LSP CompletionItemKind for references (anchors)
+const lspCompletionKindReference = 18
+
+// completionPrefixPattern matches a partially typed markdown link target before the cursor
+var completionPrefixPattern = regexp.MustCompile(`\]\(([^)\s]*)$`)
+
+// LSPServer serves validator diagnostics to editors over the Language Server Protocol
+type LSPServer struct {
+	config *Config
+	links  *LinkValidator
+	root   string
+	pinned bool // The root was configured, so the client's root doesn't replace it
+
+	mu    sync.Mutex
+	out   io.Writer
+	docs  map[string]*lspDocument // Open documents keyed by URI
+	files []string                // Repo file index used for fixes and completion
+}
+
+// lspDocument is an open editor buffer and the fixes computed for it
+type lspDocument struct {
+	text  string
+	fixes []lspFix
+}
+
+// lspFix ties a Fix to the diagnostic it resolves
+type lspFix struct {
+	fix        *Fix
+	diagnostic lspDiagnostic
+}
+
+type lspMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspTextDocumentPosition struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position lspPosition `json:"position"`
+}
+
+// NewLSPServer creates a new LSPServer. The workspace root is config.RepoRoot
+// when set; otherwise it defaults to the repository root until the client
+// sends one in initialize.
+func NewLSPServer(config *Config) *LSPServer {
+	links := NewLinkValidator(config)
+	return &LSPServer{
+		config: links.config,
+		links:  links,
+		root:   links.ws.root,
+		pinned: config.RepoRoot != "",
+		docs:   make(map[string]*lspDocument),
+	}
+}
+
+// Serve reads LSP messages from r and writes responses to w until the client
+// sends exit or closes the stream
+func (s *LSPServer) Serve(r io.Reader, w io.Writer) error {
+	s.out = w
+	reader := textproto.NewReader(bufio.NewReader(r))
+
+	for {
+		headers, err := reader.ReadMIMEHeader()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read LSP headers: %w", err)
+		}
+
+		length, err := strconv.Atoi(headers.Get("Content-Length"))
+		if err != nil {
+			return fmt.Errorf("invalid Content-Length: %w", err)
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader.R, body); err != nil {
+			return fmt.Errorf("failed to read LSP message: %w", err)
+		}
+
+		var msg lspMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return fmt.Errorf("invalid LSP message: %w", err)
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+		s.handle(msg)
+	}
+}
+
+// handle dispatches a single request or notification
+func (s *LSPServer) handle(msg lspMessage) {
+	var result any
+	var rpcErr *lspError
+
+	switch msg.Method {
+	case "initialize":
+		result = s.initialize(msg.Params)
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		// Notifications with nothing to do
+	case "shutdown":
+		result = nil
+	case "textDocument/didOpen":
+		var params struct {
+			TextDocument struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"textDocument"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			s.refreshFiles()
+			s.update(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		var params struct {
+			TextDocument struct {
+				URI string `json:"uri"`
+			} `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		// Full document sync: the last change carries the whole text
+		if err := json.Unmarshal(msg.Params, &params); err == nil && len(params.ContentChanges) > 0 {
+			s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+	case "textDocument/didSave":
+		var params lspTextDocumentPosition
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			s.refreshFiles()
+			if doc := s.document(params.TextDocument.URI); doc != nil {
+				s.update(params.TextDocument.URI, doc.text)
+			}
+		}
+	case "textDocument/didClose":
+		var params lspTextDocumentPosition
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			s.mu.Lock()
+			delete(s.docs, params.TextDocument.URI)
+			s.mu.Unlock()
+			s.publish(params.TextDocument.URI, nil)
+		}
+	case "textDocument/definition":
+		var params lspTextDocumentPosition
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			result = s.definition(params)
+		}
+	case "textDocument/codeAction":
+		var params struct {
+			TextDocument struct {
+				URI string `json:"uri"`
+			} `json:"textDocument"`
+			Range lspRange `json:"range"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			result = s.codeActions(params.TextDocument.URI, params.Range)
+		}
+	case "textDocument/completion":
+		var params lspTextDocumentPosition
+		if err := json.Unmarshal(msg.Params, &params); err == nil {
+			result = s.completion(params)
+		}
+	default:
+		rpcErr = &lspError{Code: -32601, Message: "method not found: " + msg.Method}
+	}
+
+	// Notifications carry no ID and get no response
+	if msg.ID == nil {
+		return
+	}
+
+	response := map[string]any{"jsonrpc": "2.0", "id": msg.ID}
+	if rpcErr != nil {
+		response["error"] = rpcErr
+	} else {
+		response["result"] = result
+	}
+	s.write(response)
+}
+
+// initialize records the workspace root, unless one was configured, and
+// advertises server capabilities
+func (s *LSPServer) initialize(raw json.RawMessage) any {
+	var params struct {
+		RootURI  string `json:"rootUri"`
+		RootPath string `json:"rootPath"`
+	}
+	_ = json.Unmarshal(raw, &params)
+
+	root := params.RootPath
+	if params.RootURI != "" {
+		root = uriToPath(params.RootURI)
+	}
+	if root != "" && !s.pinned {
+		config := *s.config
+		config.RepoRoot = root
+		s.links = NewLinkValidator(&config)
+		s.config = s.links.config
+		s.root = s.links.ws.root
+	}
+	s.refreshFiles()
+
+	return map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync":   1, // Full
+			"definitionProvider": true,
+			"codeActionProvider": true,
+			"completionProvider": map[string]any{
+				"triggerCharacters": []string{"(", "/", "#"},
+			},
+		},
+		"serverInfo": map[string]any{"name": "genesis-validator"},
+	}
+}
+
+// refreshFiles rebuilds the repo file index
+func (s *LSPServer) refreshFiles() {
+	s.links.refreshFiles()
+	var files []string
+	if repo, err := s.links.repoFiles(context.Background()); err == nil {
+		for _, file := range repo.files {
+			if !isExcludedPath(file) {
+				files = append(files, file)
+			}
+		}
+	}
+	s.mu.Lock()
+	s.files = files
+	s.mu.Unlock()
+}
+
+// document returns the open document for uri, or nil
+func (s *LSPServer) document(uri string) *lspDocument {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.docs[uri]
+}
+
+// update stores new document text and publishes fresh diagnostics
+func (s *LSPServer) update(uri, text string) {
+	fixes, diagnostics := s.diagnose(uri, text)
+
+	s.mu.Lock()
+	s.docs[uri] = &lspDocument{text: text, fixes: fixes}
+	s.mu.Unlock()
+
+	s.publish(uri, diagnostics)
+}
+
+// publish sends diagnostics for a document to the client
+func (s *LSPServer) publish(uri string, diagnostics []lspDiagnostic) {
+	if diagnostics == nil {
+		diagnostics = []lspDiagnostic{}
+	}
+	s.write(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "textDocument/publishDiagnostics",
+		"params":  map[string]any{"uri": uri, "diagnostics": diagnostics},
+	})
+}
+
+// diagnose runs the link, anchor and placeholder checks on a document buffer
+func (s *LSPServer) diagnose(uri, text string) ([]lspFix, []lspDiagnostic) {
+	source := s.relPath(uri)
+	lines := strings.Split(text, "\n")
+
+	s.mu.Lock()
+	files := s.files
+	s.mu.Unlock()
+
+	var fixes []lspFix
+	var diagnostics []lspDiagnostic
+	add := func(d lspDiagnostic, fix *Fix) {
+		d.Source = "genesis-validator"
+		diagnostics = append(diagnostics, d)
+		if fix != nil {
+			fixes = append(fixes, lspFix{fix: fix, diagnostic: d})
+		}
+	}
+
+	links, _ := extractLinksFrom(strings.NewReader(text))
+	headings, _ := extractHeadings(strings.NewReader(text))
+
+	for _, link := range links {
+		rng := linkRange(lines, link)
+
+		if broken := s.links.validateLink(source, link); broken != nil {
+			add(lspDiagnostic{Range: rng, Severity: lspSeverityError, Code: "broken_link", Message: broken.Reason},
+				suggestLinkFix(source, link, files))
+			continue
+		}
+
+		targetHeadings, ok := s.anchorTarget(source, link, headings)
+		if !ok {
+			continue
+		}
+		anchor := link.url[strings.Index(link.url, "#")+1:]
+		if findAnchor(targetHeadings, anchor) == nil {
+			add(lspDiagnostic{Range: rng, Severity: lspSeverityWarning, Code: "missing_anchor",
+				Message: "Anchor not found: #" + anchor},
+				suggestAnchorFix(source, link, targetHeadings))
+		}
+	}
+
+	if !s.isGenesisDoc(source) {
+		placeholders, _ := findPlaceholders(strings.NewReader(text))
+		for _, p := range placeholders {
+			line := lines[p.Line-1]
+			start := utf16Len(line[:p.Column])
+			add(lspDiagnostic{
+				Range: lspRange{
+					Start: lspPosition{Line: p.Line - 1, Character: start},
+					End:   lspPosition{Line: p.Line - 1, Character: start + utf16Len(p.Text)},
+				},
+				Severity: lspSeverityWarning,
+				Code:     "unreplaced_placeholder",
+				Message:  "Unreplaced template variable: " + p.Text,
+			}, nil)
+		}
+	}
+
+	return suppressDiagnostics(source, text, fixes, diagnostics)
+}
+
+// suppressDiagnostics drops diagnostics covered by inline suppression comments
+// and adds diagnostics for malformed suppressions
+func suppressDiagnostics(source, text string, fixes []lspFix, diagnostics []lspDiagnostic) ([]lspFix, []lspDiagnostic) {
+	suppressions, problems := parseSuppressions(source, []byte(text))
+
+	covered := func(d lspDiagnostic) bool {
+		inc := Inconsistency{Type: d.Code, File: source, Line: d.Range.Start.Line + 1}
+		for _, s := range suppressions {
+			if s.covers(inc) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var keptDiagnostics []lspDiagnostic
+	for _, d := range diagnostics {
+		if !covered(d) {
+			keptDiagnostics = append(keptDiagnostics, d)
+		}
+	}
+	var keptFixes []lspFix
+	for _, f := range fixes {
+		if !covered(f.diagnostic) {
+			keptFixes = append(keptFixes, f)
+		}
+	}
+
+	for _, p := range problems {
+		keptDiagnostics = append(keptDiagnostics, lspDiagnostic{
+			Range: lspRange{
+				Start: lspPosition{Line: p.Line - 1},
+				End:   lspPosition{Line: p.Line - 1, Character: utf16Len(strings.Split(text, "\n")[p.Line-1])},
+			},
+			Severity: lspSeverityWarning,
+			Code:     p.Type,
+			Source:   "genesis-validator",
+			Message:  p.Description,
+		})
+	}
+
+	return keptFixes, keptDiagnostics
+}
+
+// anchorTarget returns the headings a link's #fragment must match. ok is false
+// when the link has no fragment or its target is not a markdown document.
+func (s *LSPServer) anchorTarget(source string, link linkInfo, own []Heading) ([]Heading, bool) {
+	idx := strings.Index(link.url, "#")
+	if idx == -1 || idx == len(link.url)-1 || strings.Contains(link.url, "://") {
+		return nil, false
+	}
+	if idx == 0 {
+		return own, true
+	}
+
+	target, ok := s.links.resolveRelative(source, link.url[:idx])
+	if !ok || !strings.HasSuffix(target, ".md") {
+		return nil, false
+	}
+	return s.headingsFor(target), true
+}
+
+// headingsFor returns the headings of a file, preferring an open buffer
+func (s *LSPServer) headingsFor(path string) []Heading {
+	var r io.Reader
+	if doc := s.document(pathToURI(filepath.Join(s.root, path))); doc != nil {
+		r = strings.NewReader(doc.text)
+	} else {
+		file, err := s.links.ws.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer func() { _ = file.Close() }()
+		r = file
+	}
+
+	headings, _ := extractHeadings(r)
+	return headings
+}
+
+// isGenesisDoc reports whether a file is part of the genesis documentation,
+// where {{VARIABLES}} are documented rather than left unreplaced
+func (s *LSPServer) isGenesisDoc(source string) bool {
+	return inGenesisRoot(s.config.GenesisRoot, source)
+}
+
+// definition resolves the markdown link under the cursor to its target
+func (s *LSPServer) definition(params lspTextDocumentPosition) any {
+	doc := s.document(params.TextDocument.URI)
+	if doc == nil {
+		return nil
+	}
+
+	link, ok := linkAt(doc.text, params.Position)
+	if !ok || strings.Contains(link.url, "://") {
+		return nil
+	}
+
+	source := s.relPath(params.TextDocument.URI)
+	path, anchor := link.url, ""
+	if idx := strings.Index(path, "#"); idx != -1 {
+		path, anchor = path[:idx], path[idx+1:]
+	}
+
+	target := source
+	var headings []Heading
+	if path == "" {
+		headings, _ = extractHeadings(strings.NewReader(doc.text))
+	} else {
+		resolved, ok := s.links.resolveRelative(source, path)
+		if !ok {
+			return nil
+		}
+		target = resolved
+		if anchor != "" {
+			headings = s.headingsFor(target)
+		}
+	}
+
+	line := 0
+	if heading := findAnchor(headings, anchor); heading != nil {
+		line = heading.Line - 1
+	}
+
+	return lspLocation{
+		URI:   pathToURI(filepath.Join(s.root, target)),
+		Range: lspRange{Start: lspPosition{Line: line}, End: lspPosition{Line: line}},
+	}
+}
+
+// codeActions returns quick fixes for diagnostics overlapping the range
+func (s *LSPServer) codeActions(uri string, rng lspRange) []map[string]any {
+	doc := s.document(uri)
+	if doc == nil {
+		return nil
+	}
+	lines := strings.Split(doc.text, "\n")
+
+	actions := []map[string]any{}
+	for _, f := range doc.fixes {
+		d := f.diagnostic
+		if d.Range.End.Line < rng.Start.Line || d.Range.Start.Line > rng.End.Line {
+			continue
+		}
+
+		edit, ok := fixEdit(lines, f.fix)
+		if !ok {
+			continue
+		}
+		actions = append(actions, map[string]any{
+			"title":       f.fix.Title,
+			"kind":        "quickfix",
+			"diagnostics": []lspDiagnostic{d},
+			"edit": map[string]any{
+				"changes": map[string][]lspTextEdit{uri: {edit}},
+			},
+		})
+	}
+
+	return actions
+}
+
+// completion offers repo file paths and heading anchors inside link targets
+func (s *LSPServer) completion(params lspTextDocumentPosition) any {
+	doc := s.document(params.TextDocument.URI)
+	if doc == nil {
+		return []any{}
+	}
+
+	lines := strings.Split(doc.text, "\n")
+	if params.Position.Line >= len(lines) {
+		return []any{}
+	}
+	line := lines[params.Position.Line]
+	before := line[:utf16ToByteOffset(line, params.Position.Character)]
+
+	match := completionPrefixPattern.FindStringSubmatch(before)
+	if match == nil {
+		return []any{}
+	}
+	prefix := match[1]
+	source := s.relPath(params.TextDocument.URI)
+
+	replace := lspRange{
+		Start: lspPosition{Line: params.Position.Line, Character: params.Position.Character - utf16Len(prefix)},
+		End:   params.Position,
+	}
+
+	var items []map[string]any
+	addItem := func(label string, kind int) {
+		items = append(items, map[string]any{
+			"label":    label,
+			"kind":     kind,
+			"textEdit": lspTextEdit{Range: replace, NewText: label},
+		})
+	}
+
+	// After '#', complete heading anchors of the target document
+	if idx := strings.Index(prefix, "#"); idx != -1 {
+		var headings []Heading
+		if idx == 0 {
+			headings, _ = extractHeadings(strings.NewReader(doc.text))
+		} else if target, ok := s.links.resolveRelative(source, prefix[:idx]); ok {
+			headings = s.headingsFor(target)
+		}
+		for _, heading := range headings {
+			addItem(prefix[:idx+1]+heading.Anchor, lspCompletionKindReference)
+		}
+		return items
+	}
+
+	s.mu.Lock()
+	files := s.files
+	s.mu.Unlock()
+
+	for _, file := range files {
+		rel := relativeLink(source, file)
+		if strings.HasPrefix(rel, prefix) && rel != filepath.ToSlash(filepath.Base(source)) {
+			addItem(rel, lspCompletionKindFile)
+		}
+	}
+
+	return items
+}
+
+// write sends a JSON-RPC message with LSP framing
+func (s *LSPServer) write(msg any) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// relPath converts a document URI to a path relative to the workspace root
+func (s *LSPServer) relPath(uri string) string {
+	path := uriToPath(uri)
+	if rel, err := filepath.Rel(s.root, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// linkAt returns the link whose source text contains pos
+func linkAt(text string, pos lspPosition) (linkInfo, bool) {
+	links, _ := extractLinksFrom(strings.NewReader(text))
+	lines := strings.Split(text, "\n")
+
+	for _, link := range links {
+		rng := linkRange(lines, link)
+		if rng.Start.Line == pos.Line && pos.Character >= rng.Start.Character && pos.Character <= rng.End.Character {
+			return link, true
+		}
+	}
+	return linkInfo{}, false
+}
+
+// linkRange returns the LSP range covering a link's [text](url) source
+func linkRange(lines []string, link linkInfo) lspRange {
+	line := lines[link.line-1]
+	start := utf16Len(line[:link.col])
+	length := utf16Len("[" + link.text + "](" + link.url + ")")
+	return lspRange{
+		Start: lspPosition{Line: link.line - 1, Character: start},
+		End:   lspPosition{Line: link.line - 1, Character: start + length},
+	}
+}
+
+// fixEdit converts a Fix into an LSP text edit
+func fixEdit(lines []string, fix *Fix) (lspTextEdit, bool) {
+	if fix.Line < 1 || fix.Line > len(lines) {
+		return lspTextEdit{}, false
+	}
+	line := lines[fix.Line-1]
+	idx := strings.Index(line, fix.Old)
+	if idx == -1 {
+		return lspTextEdit{}, false
+	}
+
+	start := utf16Len(line[:idx])
+	return lspTextEdit{
+		Range: lspRange{
+			Start: lspPosition{Line: fix.Line - 1, Character: start},
+			End:   lspPosition{Line: fix.Line - 1, Character: start + utf16Len(fix.Old)},
+		},
+		NewText: fix.New,
+	}, true
+}
+
+// utf16Len returns the length of s in UTF-16 code units, as LSP positions require
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// utf16ToByteOffset converts a UTF-16 character offset within line to a byte offset
+func utf16ToByteOffset(line string, char int) int {
+	units := 0
+	for i, r := range line {
+		if units >= char {
+			return i
+		}
+		if r >= 0x10000 {
+			units += 2
+		} else {
+			units++
+		}
+	}
+	return len(line)
+}
+
+// uriToPath converts a file:// URI to a local path
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// pathToURI converts a local path to a file:// URI
+func pathToURI(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()
+}
@@ -0,0 +1,246 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// lspSession frames client messages and decodes server output
+type lspSession struct {
+	in     bytes.Buffer
+	nextID int
+}
+
+func (c *lspSession) send(method string, params any, request bool) {
+	msg := map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+	if request {
+		c.nextID++
+		msg["id"] = c.nextID
+	}
+	body, _ := json.Marshal(msg)
+	fmt.Fprintf(&c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// readLSPMessages decodes every framed message written by the server
+func readLSPMessages(t *testing.T, out []byte) []map[string]json.RawMessage {
+	t.Helper()
+	reader := textproto.NewReader(bufio.NewReader(bytes.NewReader(out)))
+
+	var messages []map[string]json.RawMessage
+	for {
+		headers, err := reader.ReadMIMEHeader()
+		if err == io.EOF {
+			return messages
+		}
+		if err != nil {
+			t.Fatalf("Failed to read headers: %v", err)
+		}
+		length, _ := strconv.Atoi(headers.Get("Content-Length"))
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader.R, body); err != nil {
+			t.Fatalf("Failed to read body: %v", err)
+		}
+		var msg map[string]json.RawMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatalf("Invalid JSON from server: %v", err)
+		}
+		messages = append(messages, msg)
+	}
+}
+
+// responseFor returns the result of the response with the given ID
+func responseFor(t *testing.T, messages []map[string]json.RawMessage, id int, v any) {
+	t.Helper()
+	for _, msg := range messages {
+		if string(msg["id"]) == strconv.Itoa(id) {
+			if err := json.Unmarshal(msg["result"], v); err != nil {
+				t.Fatalf("Failed to decode result %d: %v", id, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("No response with id %d", id)
+}
+
+func TestLSPServer_Session(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "docs/guide.md", "# Guide\n\n## Getting Started\n")
+	writeTestFile(t, dir, "docs/sub/other.md", "# Other\n")
+	chdir(t, dir)
+
+	root, _ := filepath.Abs(".")
+	docURI := pathToURI(filepath.Join(root, "README.md"))
+	text := strings.Join([]string{
+		"# Readme",
+		"",
+		"[Guide](docs/guide.md#getting-startd)",
+		"[Other](other.md)",
+		"Hello {{PROJECT_NAME}}",
+		"[Self](#readme) and [x](docs/",
+	}, "\n")
+
+	var c lspSession
+	c.send("initialize", map[string]any{"rootUri": pathToURI(root)}, true)
+	c.send("initialized", map[string]any{}, false)
+	c.send("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": docURI, "languageId": "markdown", "version": 1, "text": text},
+	}, false)
+	c.send("textDocument/codeAction", map[string]any{
+		"textDocument": map[string]any{"uri": docURI},
+		"range":        lspRange{Start: lspPosition{Line: 2}, End: lspPosition{Line: 3}},
+		"context":      map[string]any{"diagnostics": []any{}},
+	}, true)
+	c.send("textDocument/definition", map[string]any{
+		"textDocument": map[string]any{"uri": docURI},
+		"position":     lspPosition{Line: 2, Character: 3},
+	}, true)
+	c.send("textDocument/completion", map[string]any{
+		"textDocument": map[string]any{"uri": docURI},
+		"position":     lspPosition{Line: 5, Character: len("[Self](#readme) and [x](docs/")},
+	}, true)
+	c.send("shutdown", nil, true)
+	c.send("exit", nil, false)
+
+	var out bytes.Buffer
+	if err := NewLSPServer(DefaultConfig()).Serve(&c.in, &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+	messages := readLSPMessages(t, out.Bytes())
+
+	// Diagnostics published on open
+	var published struct {
+		URI         string          `json:"uri"`
+		Diagnostics []lspDiagnostic `json:"diagnostics"`
+	}
+	for _, msg := range messages {
+		if string(msg["method"]) == `"textDocument/publishDiagnostics"` {
+			_ = json.Unmarshal(msg["params"], &published)
+		}
+	}
+	codes := make(map[string]int)
+	for _, d := range published.Diagnostics {
+		codes[d.Code]++
+	}
+	if codes["broken_link"] != 1 || codes["missing_anchor"] != 1 || codes["unreplaced_placeholder"] != 1 {
+		t.Errorf("unexpected diagnostics: %+v", published.Diagnostics)
+	}
+
+	// Quick fixes for the broken link and the misspelled anchor
+	var actions []struct {
+		Title string `json:"title"`
+		Edit  struct {
+			Changes map[string][]lspTextEdit `json:"changes"`
+		} `json:"edit"`
+	}
+	responseFor(t, messages, 2, &actions)
+	edits := make(map[string]bool)
+	for _, action := range actions {
+		for _, edit := range action.Edit.Changes[docURI] {
+			edits[edit.NewText] = true
+		}
+	}
+	if !edits["](docs/sub/other.md)"] || !edits["](docs/guide.md#getting-started)"] {
+		t.Errorf("unexpected code actions: %+v", actions)
+	}
+
+	// Go-to-definition lands on the target file
+	var location lspLocation
+	responseFor(t, messages, 3, &location)
+	if !strings.HasSuffix(location.URI, "/docs/guide.md") {
+		t.Errorf("definition URI = %s", location.URI)
+	}
+
+	// Completion lists files under the typed directory
+	var items []struct {
+		Label string `json:"label"`
+	}
+	responseFor(t, messages, 4, &items)
+	labels := make(map[string]bool)
+	for _, item := range items {
+		labels[item.Label] = true
+	}
+	if !labels["docs/guide.md"] || !labels["docs/sub/other.md"] || len(items) != 2 {
+		t.Errorf("unexpected completion items: %+v", items)
+	}
+}
+
+func TestLSPServer_ConfiguredRoot(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "docs/guide.md", "# Guide\n")
+	chdir(t, t.TempDir())
+
+	// The client's workspace root is elsewhere; the configured root wins
+	config := DefaultConfig()
+	config.RepoRoot = dir
+	config.GenesisRoot = "handbook"
+	docURI := pathToURI(filepath.Join(dir, "handbook", "intro.md"))
+
+	var c lspSession
+	c.send("initialize", map[string]any{"rootUri": pathToURI(t.TempDir())}, true)
+	c.send("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": docURI, "languageId": "markdown", "version": 1,
+			"text": "# Intro\n\n[Guide](../docs/guide.md)\nHello {{PROJECT_NAME}}\n[Gone](gone.md)\n"},
+	}, false)
+	c.send("exit", nil, false)
+
+	var out bytes.Buffer
+	if err := NewLSPServer(config).Serve(&c.in, &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	var published struct {
+		Diagnostics []lspDiagnostic `json:"diagnostics"`
+	}
+	for _, msg := range readLSPMessages(t, out.Bytes()) {
+		if string(msg["method"]) == `"textDocument/publishDiagnostics"` {
+			_ = json.Unmarshal(msg["params"], &published)
+		}
+	}
+	var got []string
+	for _, d := range published.Diagnostics {
+		got = append(got, fmt.Sprintf("%d %s", d.Range.Start.Line, d.Code))
+	}
+	// Placeholders are expected under the configured genesis root
+	if want := []string{"4 broken_link"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("diagnostics = %v, want %v", got, want)
+	}
+}
+
+func TestExtractHeadings_Anchors(t *testing.T) {
+	content := "# Getting Started!\n```\n# not a heading\n```\n## Step 1: `cp` files\n## Getting Started\n"
+
+	headings, err := extractHeadings(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("extractHeadings() error = %v", err)
+	}
+
+	want := []string{"getting-started", "step-1-cp-files", "getting-started-1"}
+	if len(headings) != len(want) {
+		t.Fatalf("extractHeadings() found %d headings, want %d: %+v", len(headings), len(want), headings)
+	}
+	for i, anchor := range want {
+		if headings[i].Anchor != anchor {
+			t.Errorf("heading %d anchor = %q, want %q", i, headings[i].Anchor, anchor)
+		}
+	}
+}
+
+func TestFindPlaceholders_SkipsCode(t *testing.T) {
+	content := "Name: {{PROJECT_NAME}}\n`{{IN_CODE}}`\n```\n{{IN_BLOCK}}\n```\n"
+
+	placeholders, err := findPlaceholders(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("findPlaceholders() error = %v", err)
+	}
+	if len(placeholders) != 1 || placeholders[0].Name != "PROJECT_NAME" || placeholders[0].Column != 6 {
+		t.Errorf("findPlaceholders() = %+v", placeholders)
+	}
+}
@@ -0,0 +1,253 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// templateTiers lists the valid manifest tiers, most important first
+var templateTiers = []string{"MANDATORY", "RECOMMENDED", "OPTIONAL"}
+
+// TemplateManifest is the machine-readable list of Genesis templates
+type TemplateManifest struct {
+	Templates []ManifestEntry `json:"templates"`
+}
+
+// ManifestEntry describes one template and where it is installed
+type ManifestEntry struct {
+	Path        string `json:"path"`        // Relative to the genesis root, e.g. templates/web-app/index-template.html
+	Tier        string `json:"tier"`        // MANDATORY, RECOMMENDED or OPTIONAL
+	Destination string `json:"destination"` // Path in the generated project, e.g. index.html
+}
+
+// LoadManifest reads a template manifest from a JSON file
+func LoadManifest(path string) (*TemplateManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseManifest(path, data)
+}
+
+// parseManifest decodes the template manifest read from path
+func parseManifest(path string, data []byte) (*TemplateManifest, error) {
+	var manifest TemplateManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid template manifest %s: %w", path, err)
+	}
+
+	return &manifest, nil
+}
+
+// loadManifest loads the configured manifest. It returns nil without error
+// when no manifest is configured or the file doesn't exist.
+func (v *Validator) loadManifest() (*TemplateManifest, error) {
+	if v.config.ManifestFile == "" {
+		return nil, nil
+	}
+	data, err := v.ws.ReadFile(v.config.ManifestFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseManifest(v.config.ManifestFile, data)
+}
+
+// validateManifest checks that the manifest, the templates on disk and the
+// START-HERE sections documenting each template agree on path, tier and
+// destination
+func (v *Validator) validateManifest(result *ValidationResult, manifest *TemplateManifest, templates []string) {
+	manifestFile := displayPath(v.config.ManifestFile)
+	content, _ := v.ws.ReadFile(v.config.ManifestFile)
+	result.markChecked("tier_mismatch", "destination_mismatch")
+	startHere := displayPath(v.config.StartHereFile)
+
+	report := func(typ, file string, line int, description string) {
+		location := file
+		if line > 0 {
+			location = fmt.Sprintf("%s:%d", file, line)
+		}
+		result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
+			Type:        typ,
+			File:        file,
+			Line:        line,
+			Description: description,
+			Location:    location,
+		})
+	}
+
+	entries := make(map[string]ManifestEntry)
+	for _, entry := range manifest.Templates {
+		line := manifestLine(content, entry.Path)
+
+		switch {
+		case entry.Path == "":
+			report("invalid_manifest", manifestFile, line, "Manifest entry has no path")
+			continue
+		case entries[entry.Path].Path != "":
+			report("invalid_manifest", manifestFile, line, fmt.Sprintf("Template %s is listed more than once", entry.Path))
+			continue
+		case !containsString(templateTiers, entry.Tier):
+			report("invalid_manifest", manifestFile, line, fmt.Sprintf("Template %s has tier %q, want one of %s", entry.Path, entry.Tier, strings.Join(templateTiers, ", ")))
+		case entry.Destination == "":
+			report("invalid_manifest", manifestFile, line, fmt.Sprintf("Template %s has no destination", entry.Path))
+		}
+		entries[entry.Path] = entry
+
+		if !v.ws.Exists(filepath.Join(v.config.GenesisRoot, entry.Path)) && !containsString(result.MissingFiles, entry.Path) {
+			result.MissingFiles = append(result.MissingFiles, entry.Path)
+			result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
+				Type:        "missing_file",
+				File:        entry.Path,
+				Description: "Listed in the template manifest but file does not exist",
+				Location:    fmt.Sprintf("%s:%d", manifestFile, line),
+			})
+		}
+	}
+
+	for _, template := range templates {
+		if _, ok := entries[template]; !ok {
+			report("unlisted_template", template, 0, fmt.Sprintf("Template file exists but is not listed in %s", manifestFile))
+		}
+	}
+
+	docs, err := v.startHereDocs()
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to read %s: %w", startHere, err))
+		return
+	}
+
+	checked := make(map[string]bool)
+	for _, entry := range manifest.Templates {
+		if entry.Path == "" || checked[entry.Path] {
+			continue
+		}
+		checked[entry.Path] = true
+
+		refs := docs.references[entry.Path]
+		if len(refs) == 0 {
+			report("undocumented_template", manifestFile, manifestLine(content, entry.Path),
+				fmt.Sprintf("Template %s is listed in the manifest but not documented in %s", entry.Path, startHere))
+			continue
+		}
+
+		for _, ref := range refs {
+			section, tier := docs.sectionAt(ref.Line)
+			switch {
+			case tier == "":
+				report("tier_mismatch", startHere, ref.Line,
+					fmt.Sprintf("%s template %s is documented in section %q, which does not state a tier", entry.Tier, entry.Path, section))
+			case tier != entry.Tier:
+				report("tier_mismatch", startHere, ref.Line,
+					fmt.Sprintf("Template %s is %s in the manifest but documented in %s section %q", entry.Path, entry.Tier, tier, section))
+			}
+		}
+
+		for _, cp := range docs.copies[entry.Path] {
+			if entry.Destination != "" && cp.destination != path.Clean(entry.Destination) {
+				report("destination_mismatch", startHere, cp.line,
+					fmt.Sprintf("Template %s is copied to %s but the manifest destination is %s", entry.Path, cp.destination, entry.Destination))
+			}
+		}
+	}
+}
+
+// templateCopy is a cp command installing a template
+type templateCopy struct {
+	destination string
+	line        int
+}
+
+// startHereDoc holds the template references, copy commands and headings of START-HERE
+type startHereDoc struct {
+	references map[string][]Reference
+	copies     map[string][]templateCopy
+	headings   []Heading
+}
+
+// startHereDocs parses START-HERE for template references, the destination of
+// every cp command and its section headings
+func (v *Validator) startHereDocs() (*startHereDoc, error) {
+	content, err := v.ws.ReadFile(v.config.StartHereFile)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &startHereDoc{
+		references: make(map[string][]Reference),
+		copies:     make(map[string][]templateCopy),
+	}
+
+	refs, err := v.parser.ParseReferenceLocations(v.config.StartHereFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		doc.references[ref.Path] = append(doc.references[ref.Path], ref)
+	}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		for _, match := range cpCommandPattern.FindAllStringSubmatch(line, -1) {
+			source := strings.TrimPrefix(path.Clean(strings.Trim(match[2], `"'`)), "genesis/")
+			destination := path.Clean(strings.Trim(match[3], `"'`))
+			if destination == "." || strings.HasSuffix(match[3], "/") {
+				destination = path.Join(destination, path.Base(source))
+			}
+			doc.copies[source] = append(doc.copies[source], templateCopy{destination: destination, line: i + 1})
+		}
+	}
+
+	doc.headings, err = extractHeadings(bytes.NewReader(content))
+	return doc, err
+}
+
+// sectionAt returns the heading of the section containing line and the tier
+// stated by the nearest enclosing heading that names one
+func (d *startHereDoc) sectionAt(line int) (section, tier string) {
+	var enclosing []Heading // Heading stack, outermost first
+	for _, h := range d.headings {
+		if h.Line > line {
+			break
+		}
+		for len(enclosing) > 0 && enclosing[len(enclosing)-1].Level >= h.Level {
+			enclosing = enclosing[:len(enclosing)-1]
+		}
+		enclosing = append(enclosing, h)
+	}
+
+	if len(enclosing) == 0 {
+		return "", ""
+	}
+	section = enclosing[len(enclosing)-1].Text
+
+	for i := len(enclosing) - 1; i >= 0; i-- {
+		upper := strings.ToUpper(enclosing[i].Text)
+		for _, t := range templateTiers {
+			if strings.Contains(upper, t) {
+				return section, t
+			}
+		}
+	}
+	return section, ""
+}
+
+// manifestLine returns the line of the manifest that lists path, or 0
+func manifestLine(content []byte, path string) int {
+	if path == "" {
+		return 0
+	}
+	quoted := []byte(`"` + path + `"`)
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		if bytes.Contains(line, quoted) {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate_TemplateManifest(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	writeTestFile(t, tmpDir, "genesis/templates/scripts/setup.sh", "#!/bin/sh")
+	writeTestFile(t, tmpDir, "genesis/START-HERE.md", strings.Join([]string{
+		"# START-HERE",              // 1
+		"",                          // 2
+		"## Core Files (MANDATORY)", // 3
+		"",                          // 4
+		"```bash",                   // 5
+		"cp genesis/templates/web-app/index-template.html index.html", // 6
+		"cp genesis/templates/web-app/js/app-template.js js/main.js",  // 7
+		"cp genesis/templates/scripts/setup.sh scripts/",              // 8
+		"```",                  // 9
+		"",                     // 10
+		"## Extras (OPTIONAL)", // 11
+		"",                     // 12
+		"cp genesis/templates/CLAUDE.md.template CLAUDE.md", // 13
+	}, "\n"))
+	writeTestFile(t, tmpDir, "genesis/template-manifest.json", `{
+  "templates": [
+    {"path": "templates/web-app/index-template.html", "tier": "MANDATORY", "destination": "index.html"},
+    {"path": "templates/web-app/js/app-template.js", "tier": "MANDATORY", "destination": "js/app.js"},
+    {"path": "templates/scripts/setup.sh", "tier": "MANDATORY", "destination": "scripts/setup.sh"},
+    {"path": "templates/CLAUDE.md.template", "tier": "RECOMMENDED", "destination": "CLAUDE.md"},
+    {"path": "templates/gone-template.md", "tier": "OPTIONAL", "destination": "gone.md"}
+  ]
+}
+`)
+	config.ManifestFile = tmpDir + "/genesis/template-manifest.json"
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// setup.sh doesn't follow the naming convention but is listed in the manifest
+	if !containsString(result.TemplateFiles, "templates/scripts/setup.sh") {
+		t.Errorf("TemplateFiles = %v, want manifest-listed setup.sh included", result.TemplateFiles)
+	}
+	if !containsString(result.MissingFiles, "templates/gone-template.md") {
+		t.Errorf("MissingFiles = %v, want templates/gone-template.md", result.MissingFiles)
+	}
+
+	got := make(map[string][]Inconsistency)
+	for _, inc := range result.Inconsistencies {
+		got[inc.Type] = append(got[inc.Type], inc)
+	}
+
+	if incs := got["destination_mismatch"]; len(incs) != 1 || incs[0].Line != 7 {
+		t.Errorf("destination_mismatch = %+v, want one at line 7", incs)
+	}
+	if incs := got["tier_mismatch"]; len(incs) != 1 || incs[0].Line != 13 {
+		t.Errorf("tier_mismatch = %+v, want one at line 13", incs)
+	}
+	if incs := got["undocumented_template"]; len(incs) != 1 || incs[0].Line != 7 {
+		t.Errorf("undocumented_template = %+v, want one at manifest line 7", incs)
+	}
+	if len(got["unlisted_template"]) != 0 || len(got["invalid_manifest"]) != 0 {
+		t.Errorf("unexpected findings: %+v", result.Inconsistencies)
+	}
+}
+
+func TestValidate_TemplateManifestUnlisted(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	writeTestFile(t, tmpDir, "genesis/template-manifest.json", `{
+  "templates": [
+    {"path": "templates/web-app/index-template.html", "tier": "REQUIRED", "destination": "index.html"}
+  ]
+}
+`)
+	config.ManifestFile = tmpDir + "/genesis/template-manifest.json"
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var unlisted, invalid int
+	for _, inc := range result.Inconsistencies {
+		switch inc.Type {
+		case "unlisted_template":
+			unlisted++
+		case "invalid_manifest":
+			invalid++
+		}
+	}
+	if unlisted != 2 {
+		t.Errorf("unlisted_template findings = %d, want 2", unlisted)
+	}
+	if invalid != 1 {
+		t.Errorf("invalid_manifest findings = %d, want 1 for the unknown tier", invalid)
+	}
+}
+
+func TestStartHereDoc_SectionAt(t *testing.T) {
+	doc := &startHereDoc{headings: []Heading{
+		{Level: 2, Text: "Required Templates (MANDATORY)", Line: 1},
+		{Level: 3, Text: "Web App", Line: 5},
+		{Level: 2, Text: "Quick Reference", Line: 20},
+	}}
+
+	tests := []struct {
+		line        int
+		wantSection string
+		wantTier    string
+	}{
+		{3, "Required Templates (MANDATORY)", "MANDATORY"},
+		{8, "Web App", "MANDATORY"},
+		{25, "Quick Reference", ""},
+	}
+
+	for _, tt := range tests {
+		section, tier := doc.sectionAt(tt.line)
+		if section != tt.wantSection || tier != tt.wantTier {
+			t.Errorf("sectionAt(%d) = %q, %q; want %q, %q", tt.line, section, tier, tt.wantSection, tt.wantTier)
+		}
+	}
+}
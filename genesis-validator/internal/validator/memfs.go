@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// memFS is a read-only in-memory file tree, used to validate git revisions
+// and archives without extracting them
+type memFS struct {
+	files map[string][]byte        // Slash-separated path -> content
+	dirs  map[string][]fs.DirEntry // Directory ("." for the root) -> sorted entries
+}
+
+// newMemFS builds a tree from file contents keyed by slash-separated path.
+// Parent directories are implied.
+func newMemFS(files map[string][]byte) *memFS {
+	m := &memFS{files: make(map[string][]byte, len(files)), dirs: map[string][]fs.DirEntry{".": nil}}
+	for name, data := range files {
+		name = path.Clean(strings.TrimPrefix(name, "/"))
+		if !fs.ValidPath(name) || name == "." {
+			continue
+		}
+		m.files[name] = data
+	}
+
+	seen := make(map[string]bool)
+	for name, data := range m.files {
+		m.add(name, memInfo{name: path.Base(name), size: int64(len(data))}, seen)
+	}
+	for _, entries := range m.dirs {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	}
+	return m
+}
+
+// add records an entry in its parent directory, creating parents as needed
+func (m *memFS) add(name string, info memInfo, seen map[string]bool) {
+	if seen[name] {
+		return
+	}
+	seen[name] = true
+
+	dir := path.Dir(name)
+	if dir != "." {
+		m.add(dir, memInfo{name: path.Base(dir), dir: true}, seen)
+	}
+	if info.dir {
+		if _, ok := m.dirs[name]; !ok {
+			m.dirs[name] = nil
+		}
+	}
+	m.dirs[dir] = append(m.dirs[dir], info)
+}
+
+// Open implements fs.FS
+func (m *memFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if data, ok := m.files[name]; ok {
+		return &memFile{info: memInfo{name: path.Base(name), size: int64(len(data))}, Reader: bytes.NewReader(data)}, nil
+	}
+	if entries, ok := m.dirs[name]; ok {
+		return &memDir{info: memInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// memInfo describes a file or directory of a memFS; it is both its
+// fs.FileInfo and its fs.DirEntry
+type memInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i memInfo) Name() string               { return i.name }
+func (i memInfo) Size() int64                { return i.size }
+func (i memInfo) ModTime() time.Time         { return time.Time{} }
+func (i memInfo) IsDir() bool                { return i.dir }
+func (i memInfo) Sys() any                   { return nil }
+func (i memInfo) Type() fs.FileMode          { return i.Mode().Type() }
+func (i memInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+func (i memInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+// memFile is an open regular file of a memFS
+type memFile struct {
+	info memInfo
+	*bytes.Reader
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+// memDir is an open directory of a memFS
+type memDir struct {
+	info    memInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return append([]fs.DirEntry(nil), rest...), nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return append([]fs.DirEntry(nil), rest[:n]...), nil
+}
@@ -2,24 +2,60 @@ package validator
 
 import (
 	"bufio"
-	"os"
+	"fmt"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 )
 
 // Parser parses documentation files to extract template references
 type Parser struct {
 	config *Config
+	ws     *workspace
 }
 
 // NewParser creates a new Parser
 func NewParser(config *Config) *Parser {
-	return &Parser{config: config}
+	ws, config := openWorkspace(config, nil)
+	return &Parser{config: config, ws: ws}
+}
+
+// Reference is a template file reference found in a documentation file
+type Reference struct {
+	Path string // Referenced template path, e.g. templates/web-app/index-template.html
+	Doc  string // Repo-relative path of the document containing the reference
+	Line int    // Line number of the reference within Doc
+}
+
+// String formats the reference location as doc:line
+func (r Reference) String() string {
+	return fmt.Sprintf("%s:%d", r.Doc, r.Line)
 }
 
 // ParseReferences extracts all template file references from documentation
 func (p *Parser) ParseReferences(docFile string) ([]string, error) {
-	file, err := os.Open(docFile)
+	locations, err := p.ParseReferenceLocations(docFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []string
+	seen := make(map[string]bool)
+	for _, ref := range locations {
+		if !seen[ref.Path] {
+			references = append(references, ref.Path)
+			seen[ref.Path] = true
+		}
+	}
+
+	return references, nil
+}
+
+// ParseReferenceLocations extracts every template file reference from a
+// documentation file along with the line it appears on
+func (p *Parser) ParseReferenceLocations(docFile string) ([]Reference, error) {
+	file, err := p.ws.Open(docFile)
 	if err != nil {
 		return nil, err
 	}
@@ -27,8 +63,8 @@ func (p *Parser) ParseReferences(docFile string) ([]string, error) {
 		_ = file.Close()
 	}()
 
-	var references []string
-	seen := make(map[string]bool)
+	doc := displayPath(docFile)
+	var references []Reference
 
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
@@ -38,12 +74,8 @@ func (p *Parser) ParseReferences(docFile string) ([]string, error) {
 		line := scanner.Text()
 
 		// Extract template references from various patterns
-		refs := p.extractReferences(line)
-		for _, ref := range refs {
-			if !seen[ref] {
-				references = append(references, ref)
-				seen[ref] = true
-			}
+		for _, ref := range p.extractReferences(line) {
+			references = append(references, Reference{Path: ref, Doc: doc, Line: lineNum})
 		}
 	}
 
@@ -123,23 +155,69 @@ func (p *Parser) extractReferences(line string) []string {
 	return refs
 }
 
-// ParseAllDocs parses all documentation files and returns a map of file -> references
-func (p *Parser) ParseAllDocs() (map[string][]string, error) {
-	result := make(map[string][]string)
-
-	// Parse START-HERE.md
-	startRefs, err := p.ParseReferences(p.config.StartHereFile)
+// ParseAllDocs parses every configured reference document and returns a map
+// of repo-relative document path -> references found in it
+func (p *Parser) ParseAllDocs() (map[string][]Reference, error) {
+	docs, err := p.ReferenceDocs()
 	if err != nil {
 		return nil, err
 	}
-	result["START-HERE.md"] = startRefs
 
-	// Parse 00-AI-MUST-READ-FIRST.md (formerly AI-EXECUTION-CHECKLIST.md)
-	checklistRefs, err := p.ParseReferences(p.config.ChecklistFile)
-	if err != nil {
-		return nil, err
+	result := make(map[string][]Reference)
+	for _, doc := range docs {
+		refs, err := p.ParseReferenceLocations(doc)
+		if err != nil {
+			return nil, err
+		}
+		result[displayPath(doc)] = refs
 	}
-	result["00-AI-MUST-READ-FIRST.md"] = checklistRefs
 
 	return result, nil
 }
+
+// ReferenceDocs expands the configured reference documents and globs into a
+// sorted, de-duplicated list of files. Plain paths must exist; globs may match
+// nothing.
+func (p *Parser) ReferenceDocs() ([]string, error) {
+	patterns := p.config.ReferenceDocs
+	if len(patterns) == 0 {
+		patterns = []string{p.config.StartHereFile, p.config.ChecklistFile}
+	}
+
+	var docs []string
+	seen := make(map[string]bool)
+	add := func(doc string) {
+		doc = filepath.Clean(doc)
+		if !seen[doc] {
+			seen[doc] = true
+			docs = append(docs, doc)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, "*?[") {
+			if _, err := p.ws.Stat(pattern); err != nil {
+				return nil, err
+			}
+			add(pattern)
+			continue
+		}
+
+		matches, err := p.ws.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference doc pattern %q: %w", pattern, err)
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			add(match)
+		}
+	}
+
+	return docs, nil
+}
+
+// displayPath formats a workspace path for findings. Workspace paths are
+// already repo-relative, so this only cleans them and uses forward slashes.
+func displayPath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
@@ -129,3 +129,52 @@ func TestParser_ParseReferences_NonExistentFile(t *testing.T) {
 		t.Error("ParseReferences() expected error for non-existent file, got nil")
 	}
 }
+
+func TestParser_ParseAllDocs_ReferenceDocGlobs(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "genesis/START-HERE.md", "# Start\n\ncp genesis/templates/a-template.js a.js\n")
+	writeTestFile(t, tmpDir, "genesis/CHECKLIST.md", "# Checklist\n")
+	writeTestFile(t, tmpDir, "genesis/steps/01-first.md", "# Step 1\n\n\nSee `templates/b-template.js`\n")
+	writeTestFile(t, tmpDir, "genesis/steps/02-second.md", "# Step 2\n")
+	chdir(t, tmpDir)
+
+	config := DefaultConfig()
+	parser := NewParser(config)
+
+	docRefs, err := parser.ParseAllDocs()
+	if err != nil {
+		t.Fatalf("ParseAllDocs() error = %v", err)
+	}
+
+	wantDocs := []string{
+		"genesis/CHECKLIST.md",
+		"genesis/START-HERE.md",
+		"genesis/steps/01-first.md",
+		"genesis/steps/02-second.md",
+	}
+	if len(docRefs) != len(wantDocs) {
+		t.Errorf("ParseAllDocs() parsed %d docs, want %d: %v", len(docRefs), len(wantDocs), docRefs)
+	}
+	for _, doc := range wantDocs {
+		if _, ok := docRefs[doc]; !ok {
+			t.Errorf("ParseAllDocs() missing results for %s", doc)
+		}
+	}
+
+	refs := docRefs["genesis/steps/01-first.md"]
+	if len(refs) != 1 || refs[0].Path != "templates/b-template.js" || refs[0].Line != 4 {
+		t.Errorf("unexpected step references: %+v", refs)
+	}
+	if got := refs[0].String(); got != "genesis/steps/01-first.md:4" {
+		t.Errorf("Reference.String() = %q", got)
+	}
+}
+
+func TestParser_ReferenceDocs_MissingPlainPath(t *testing.T) {
+	config := DefaultConfig()
+	config.ReferenceDocs = []string{"/nonexistent/START-HERE.md"}
+
+	if _, err := NewParser(config).ReferenceDocs(); err == nil {
+		t.Error("ReferenceDocs() expected error for missing document")
+	}
+}
@@ -0,0 +1,532 @@
+package validator
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FilePatch is the change a unified diff makes to one file
+type FilePatch struct {
+	OldPath string // Slash-separated path before the change; "" when the file is created
+	NewPath string // Slash-separated path after the change; "" when the file is deleted
+	Hunks   []Hunk
+}
+
+// Hunk is one block of changes in a FilePatch
+type Hunk struct {
+	OldStart int      // 1-based line the hunk starts at in the old file, a hint for where to apply it
+	Lines    []string // Lines prefixed with ' ' (context), '-' (removed) or '+' (added)
+	OldNoEOL bool     // The old file's last line in the hunk has no trailing newline
+	NewNoEOL bool     // The new file's last line in the hunk has no trailing newline
+}
+
+// ParsePatch parses a unified diff as produced by git diff or diff -u. Hunk
+// line counts are not trusted, since hand- and model-written diffs often get
+// them wrong; a hunk runs until the next hunk or file header. Paths must stay
+// inside the repository.
+func ParsePatch(diff string) ([]FilePatch, error) {
+	lines := strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n")
+	var patches []FilePatch
+	var file *FilePatch
+	var hunk *Hunk
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			oldPath, err := patchPath(line[4:])
+			if err != nil {
+				return nil, err
+			}
+			newPath, err := patchPath(lines[i+1][4:])
+			if err != nil {
+				return nil, err
+			}
+			if oldPath == "" && newPath == "" {
+				return nil, fmt.Errorf("line %d: both sides of the diff are /dev/null", i+1)
+			}
+			patches = append(patches, FilePatch{OldPath: oldPath, NewPath: newPath})
+			file, hunk = &patches[len(patches)-1], nil
+			i++
+
+		case strings.HasPrefix(line, "@@"):
+			if file == nil {
+				return nil, fmt.Errorf("line %d: hunk before any file header", i+1)
+			}
+			start, err := hunkStart(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			file.Hunks = append(file.Hunks, Hunk{OldStart: start})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+
+		case hunk != nil && strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file" applies to the line before it
+			if n := len(hunk.Lines); n > 0 {
+				switch hunk.Lines[n-1][0] {
+				case '-':
+					hunk.OldNoEOL = true
+				case '+':
+					hunk.NewNoEOL = true
+				default:
+					hunk.OldNoEOL, hunk.NewNoEOL = true, true
+				}
+			}
+
+		case hunk != nil && line != "" && strings.ContainsRune(" -+", rune(line[0])):
+			hunk.Lines = append(hunk.Lines, line)
+
+		case hunk != nil && line == "" && i < len(lines)-1:
+			// Editors and models often strip the space from blank context lines
+			hunk.Lines = append(hunk.Lines, " ")
+
+		default:
+			// git headers (diff --git, index, mode lines) and surrounding text
+			hunk = nil
+		}
+	}
+
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("no file changes found in diff")
+	}
+	for _, p := range patches {
+		if len(p.Hunks) == 0 {
+			return nil, fmt.Errorf("no hunks for %s", p.path())
+		}
+	}
+	return patches, nil
+}
+
+// path returns the file a patch is about
+func (p FilePatch) path() string {
+	if p.NewPath != "" {
+		return p.NewPath
+	}
+	return p.OldPath
+}
+
+// patchPath parses the path of a ---/+++ header, dropping a/ and b/
+// prefixes and trailing timestamps. It returns "" for /dev/null.
+func patchPath(header string) (string, error) {
+	name, _, _ := strings.Cut(header, "\t")
+	name = strings.TrimSpace(name)
+	if unquoted, err := strconv.Unquote(name); err == nil {
+		name = unquoted
+	}
+	if name == "/dev/null" {
+		return "", nil
+	}
+	if strings.HasPrefix(name, "a/") || strings.HasPrefix(name, "b/") {
+		name = name[2:]
+	}
+
+	clean := path.Clean(name)
+	if !fs.ValidPath(clean) || clean == "." || clean == ".git" || strings.HasPrefix(clean, ".git/") {
+		return "", fmt.Errorf("invalid path in diff: %s", name)
+	}
+	return clean, nil
+}
+
+// hunkStart parses the old start line of a hunk header "@@ -l,s +l,s @@"
+func hunkStart(header string) (int, error) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") {
+		return 0, fmt.Errorf("invalid hunk header: %s", header)
+	}
+	start, _, _ := strings.Cut(fields[1][1:], ",")
+	n, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hunk header: %s", header)
+	}
+	return n, nil
+}
+
+// applyPatches applies patches to a copy of files, keyed by slash-separated
+// path. It returns the new files and the paths it changed.
+func applyPatches(files map[string][]byte, patches []FilePatch) (map[string][]byte, []string, error) {
+	result := make(map[string][]byte, len(files))
+	for name, data := range files {
+		result[name] = data
+	}
+
+	var changed []string
+	for _, p := range patches {
+		var old []byte
+		if p.OldPath != "" {
+			data, ok := result[p.OldPath]
+			if !ok {
+				return nil, nil, fmt.Errorf("%s: no such file", p.OldPath)
+			}
+			old = data
+		} else if _, ok := result[p.NewPath]; ok {
+			return nil, nil, fmt.Errorf("%s: already exists", p.NewPath)
+		}
+
+		data, err := applyHunks(old, p.Hunks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", p.path(), err)
+		}
+
+		if p.OldPath != "" && p.OldPath != p.NewPath {
+			delete(result, p.OldPath)
+			changed = append(changed, p.OldPath)
+		}
+		if p.NewPath != "" {
+			result[p.NewPath] = data
+			changed = append(changed, p.NewPath)
+		}
+	}
+	return result, changed, nil
+}
+
+// applyHunks applies hunks in order to file content. Each hunk is placed
+// where its context and removed lines match, searching outward from the
+// line its header gives.
+func applyHunks(content []byte, hunks []Hunk) ([]byte, error) {
+	lines := splitLines(content)
+	if len(content) > 0 && len(lines) == 0 {
+		lines = []string{""} // A single blank line
+	}
+	eol := len(content) == 0 || content[len(content)-1] == '\n'
+	next := 0 // Hunks apply in order and can't overlap
+
+	for i, hunk := range hunks {
+		var before, after []string
+		for _, line := range hunk.Lines {
+			switch line[0] {
+			case ' ':
+				before, after = append(before, line[1:]), append(after, line[1:])
+			case '-':
+				before = append(before, line[1:])
+			case '+':
+				after = append(after, line[1:])
+			}
+		}
+
+		at := findLines(lines, before, next, hunk.OldStart-1)
+		if at < 0 {
+			return nil, fmt.Errorf("hunk %d does not apply", i+1)
+		}
+		end := at + len(before)
+		if end == len(lines) && (hunk.OldNoEOL || hunk.NewNoEOL) {
+			eol = !hunk.NewNoEOL
+		}
+
+		// Context lines keep the file's text, which may differ in trailing whitespace
+		replacement := make([]string, 0, len(after))
+		pos := at
+		for _, line := range hunk.Lines {
+			switch line[0] {
+			case ' ':
+				replacement = append(replacement, lines[pos])
+				pos++
+			case '-':
+				pos++
+			case '+':
+				replacement = append(replacement, line[1:])
+			}
+		}
+
+		lines = append(lines[:at], append(replacement, lines[end:]...)...)
+		next = at + len(replacement)
+	}
+
+	return joinLines(lines, eol), nil
+}
+
+// findLines returns the index at or after from where want occurs in lines,
+// preferring the one nearest hint, or -1. Failing an exact match, trailing
+// whitespace is ignored.
+func findLines(lines, want []string, from, hint int) int {
+	for _, normalize := range []func(string) string{
+		func(s string) string { return s },
+		func(s string) string { return strings.TrimRight(s, " \t") },
+	} {
+		matches := func(at int) bool {
+			if at < from || at+len(want) > len(lines) {
+				return false
+			}
+			for j, line := range want {
+				if normalize(lines[at+j]) != normalize(line) {
+					return false
+				}
+			}
+			return true
+		}
+
+		hint := max(hint, from)
+		for d := 0; hint-d >= from || hint+d <= len(lines); d++ {
+			if matches(hint - d) {
+				return hint - d
+			}
+			if matches(hint + d) {
+				return hint + d
+			}
+		}
+	}
+	return -1
+}
+
+// joinLines joins lines with newlines, ending with one when eol is set
+func joinLines(lines []string, eol bool) []byte {
+	if len(lines) == 0 {
+		return nil
+	}
+	text := strings.Join(lines, "\n")
+	if eol {
+		text += "\n"
+	}
+	return []byte(text)
+}
+
+// diffOp is one line of a file diff
+type diffOp struct {
+	kind  byte // ' ' (context), '-' (removed) or '+' (added)
+	text  string
+	noEOL bool // The line ends its file without a newline
+}
+
+// replacement is a fix located on its line
+type replacement struct {
+	start, end int
+	fix        Fix
+}
+
+// mergeFixes applies fixes to file content and returns the diff of the
+// change, one op per line, with the fixes applied and skipped. Fixes on the
+// same line are combined; one that overlaps an earlier fix differently, or
+// whose text is no longer on its line, is skipped. Duplicates are merged.
+func mergeFixes(content []byte, fixes []Fix) ([]diffOp, []Fix, []Fix) {
+	lines := splitLines(content)
+	if len(content) > 0 && len(lines) == 0 {
+		lines = []string{""} // A single blank line
+	}
+
+	var applied, skipped []Fix
+	inserts := make(map[int][]string)
+	replacements := make(map[int][]replacement)
+
+	for _, fix := range fixes {
+		if fix.Old == "" {
+			if fix.Line < 1 || fix.Line > len(lines)+1 {
+				skipped = append(skipped, fix)
+				continue
+			}
+			applied = append(applied, fix)
+			for _, text := range inserts[fix.Line-1] {
+				if text == fix.New {
+					fix.New = "" // Merged with the identical insertion
+				}
+			}
+			if fix.New != "" {
+				inserts[fix.Line-1] = append(inserts[fix.Line-1], fix.New)
+			}
+			continue
+		}
+
+		if fix.Line < 1 || fix.Line > len(lines) {
+			skipped = append(skipped, fix)
+			continue
+		}
+		r, ok, merged := locateFix(lines[fix.Line-1], fix, replacements[fix.Line-1])
+		switch {
+		case merged:
+			applied = append(applied, fix)
+		case ok:
+			applied = append(applied, fix)
+			replacements[fix.Line-1] = append(replacements[fix.Line-1], r)
+		default:
+			skipped = append(skipped, fix)
+		}
+	}
+
+	var ops []diffOp
+	added := func(text string) {
+		for _, line := range strings.Split(text, "\n") {
+			ops = append(ops, diffOp{kind: '+', text: line})
+		}
+	}
+	for i := 0; i <= len(lines); i++ {
+		for _, text := range inserts[i] {
+			added(text)
+		}
+		if i == len(lines) {
+			break
+		}
+
+		line := lines[i]
+		rs := replacements[i]
+		sort.Slice(rs, func(a, b int) bool { return rs[a].start < rs[b].start })
+		var b strings.Builder
+		pos := 0
+		for _, r := range rs {
+			b.WriteString(line[pos:r.start])
+			b.WriteString(r.fix.New)
+			pos = r.end
+		}
+		b.WriteString(line[pos:])
+
+		if changed := b.String(); changed != line {
+			ops = append(ops, diffOp{kind: '-', text: line})
+			added(changed)
+		} else {
+			ops = append(ops, diffOp{kind: ' ', text: line})
+		}
+	}
+
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		ops = markNoEOL(ops)
+	}
+	return ops, applied, skipped
+}
+
+// locateFix finds the text a fix replaces on its line, given the
+// replacements already placed there. It prefers the fix's column, then the
+// first occurrence not taken by an identical fix. merged reports that an
+// identical replacement is already in place.
+func locateFix(line string, fix Fix, placed []replacement) (r replacement, ok, merged bool) {
+	taken := func(start int) bool {
+		for _, p := range placed {
+			if p.start == start && p.fix.Old == fix.Old {
+				return true
+			}
+		}
+		return false
+	}
+
+	start := -1
+	if c := fix.Column - 1; c >= 0 && strings.HasPrefix(line[min(c, len(line)):], fix.Old) && !taken(c) {
+		start = c
+	}
+	for from := 0; start < 0; {
+		idx := strings.Index(line[from:], fix.Old)
+		if idx == -1 {
+			break
+		}
+		if !taken(from + idx) {
+			start = from + idx
+		}
+		from += idx + 1
+	}
+	if start < 0 {
+		// Every occurrence is taken; an identical fix needs no second edit
+		for _, p := range placed {
+			if p.fix.Old == fix.Old && p.fix.New == fix.New {
+				return replacement{}, false, true
+			}
+		}
+		return replacement{}, false, false
+	}
+
+	r = replacement{start: start, end: start + len(fix.Old), fix: fix}
+	for _, p := range placed {
+		if r.start < p.end && p.start < r.end {
+			return replacement{}, false, false
+		}
+	}
+	return r, true, false
+}
+
+// markNoEOL marks the last line of each side of a diff whose file ends
+// without a newline. Lines appended after it give it a newline in the new
+// file, so it is then removed and added back rather than kept as context.
+func markNoEOL(ops []diffOp) []diffOp {
+	lastOld, lastNew := -1, -1
+	for i, op := range ops {
+		if op.kind != '+' {
+			lastOld = i
+		}
+		if op.kind != '-' {
+			lastNew = i
+		}
+	}
+	if lastOld >= 0 && ops[lastOld].kind == ' ' && lastOld != lastNew {
+		ops[lastOld].kind = '-'
+		kept := diffOp{kind: '+', text: ops[lastOld].text}
+		ops = append(ops[:lastOld+1], append([]diffOp{kept}, ops[lastOld+1:]...)...)
+		lastNew++
+	}
+	if lastOld >= 0 {
+		ops[lastOld].noEOL = true
+	}
+	if lastNew >= 0 {
+		ops[lastNew].noEOL = true
+	}
+	return ops
+}
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// formatDiff renders the ops of one file as a git-style unified diff, or ""
+// when nothing changed. Changes at most twice the context apart share a
+// hunk.
+func formatDiff(name string, ops []diffOp) string {
+	var changes []int
+	for i, op := range ops {
+		if op.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	// Line numbers before each op, on each side
+	oldLine := make([]int, len(ops)+1)
+	newLine := make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if op.kind != '+' {
+			oldLine[i+1]++
+		}
+		if op.kind != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	oldName, newName := diffPath("a/"+name), diffPath("b/"+name)
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git %s %s\n--- %s\n+++ %s\n", oldName, newName, oldName, newName)
+
+	for i := 0; i < len(changes); {
+		j := i
+		for j+1 < len(changes) && changes[j+1]-changes[j] <= 2*diffContext+1 {
+			j++
+		}
+		start, end := max(changes[i]-diffContext, 0), min(changes[j]+diffContext+1, len(ops))
+
+		oldCount, newCount := oldLine[end]-oldLine[start], newLine[end]-newLine[start]
+		oldStart, newStart := oldLine[start]+1, newLine[start]+1
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, op := range ops[start:end] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.text)
+			b.WriteByte('\n')
+			if op.noEOL {
+				b.WriteString("\\ No newline at end of file\n")
+			}
+		}
+		i = j + 1
+	}
+	return b.String()
+}
+
+// diffPath quotes a path for a diff header, as git does, when it holds
+// characters that would otherwise be misread
+func diffPath(name string) string {
+	if strings.ContainsAny(name, "\"\\\t\n") {
+		return strconv.Quote(name)
+	}
+	return name
+}
@@ -0,0 +1,343 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePatch(t *testing.T) {
+	diff := `Here is the fix:
+
+diff --git a/docs/a.md b/docs/a.md
+index 1234567..89abcde 100644
+--- a/docs/a.md
++++ b/docs/a.md
+@@ -1,3 +1,3 @@
+ # A
+
+-[b](b.md)
++[b](../b.md)
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++# New
+\ No newline at end of file
+`
+	patches, err := ParsePatch(diff)
+	if err != nil {
+		t.Fatalf("ParsePatch() error = %v", err)
+	}
+	want := []FilePatch{
+		{OldPath: "docs/a.md", NewPath: "docs/a.md", Hunks: []Hunk{{OldStart: 1, Lines: []string{" # A", " ", "-[b](b.md)", "+[b](../b.md)"}}}},
+		{NewPath: "docs/new.md", Hunks: []Hunk{{OldStart: 0, Lines: []string{"+# New"}, NewNoEOL: true}}},
+	}
+	if !reflect.DeepEqual(patches, want) {
+		t.Errorf("ParsePatch() = %+v, want %+v", patches, want)
+	}
+
+	for _, bad := range []string{
+		"no diff here",
+		"--- a/../outside.md\n+++ b/../outside.md\n@@ -1 +1 @@\n-a\n+b\n",
+		"--- a/.git/config\n+++ b/.git/config\n@@ -1 +1 @@\n-a\n+b\n",
+		"--- /etc/passwd\n+++ /etc/passwd\n@@ -1 +1 @@\n-a\n+b\n",
+		"--- a/x.md\n+++ b/x.md\n",
+	} {
+		if _, err := ParsePatch(bad); err == nil {
+			t.Errorf("ParsePatch(%q) error = nil, want error", bad)
+		}
+	}
+}
+
+func TestApplyPatches(t *testing.T) {
+	files := map[string][]byte{
+		"a.md":    []byte("one\ntwo\nthree\nfour\nfive\nsix\n"),
+		"gone.md": []byte("bye\n"),
+		"tail.md": []byte("x\ny"),
+	}
+
+	tests := []struct {
+		name string
+		diff string
+		want map[string]string // Changed files; "" for deleted
+	}{
+		{
+			name: "wrong line numbers and counts",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ -1,2 +1,9 @@\n four\n-five\n+FIVE\n six\n",
+			want: map[string]string{"a.md": "one\ntwo\nthree\nfour\nFIVE\nsix\n"},
+		},
+		{
+			name: "two hunks",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ -1 +1,2 @@\n one\n+one and a half\n@@ -6 +7 @@\n-six\n+6\n",
+			want: map[string]string{"a.md": "one\none and a half\ntwo\nthree\nfour\nfive\n6\n"},
+		},
+		{
+			name: "trailing whitespace stripped from context",
+			diff: "--- a/a.md\n+++ b/a.md\n@@ -2 +2 @@\n two   \n-three\n+3\n",
+			want: map[string]string{"a.md": "one\ntwo\n3\nfour\nfive\nsix\n"},
+		},
+		{
+			name: "create and delete",
+			diff: "--- /dev/null\n+++ b/new.md\n@@ -0,0 +1 @@\n+hi\n--- a/gone.md\n+++ /dev/null\n@@ -1 +0,0 @@\n-bye\n",
+			want: map[string]string{"new.md": "hi\n", "gone.md": ""},
+		},
+		{
+			name: "no newline at end of file",
+			diff: "--- a/tail.md\n+++ b/tail.md\n@@ -1,2 +1,2 @@\n x\n-y\n\\ No newline at end of file\n+z\n",
+			want: map[string]string{"tail.md": "x\nz\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patched, changed, err := applyDiff(files, tt.diff)
+			if err != nil {
+				t.Fatalf("applyDiff() error = %v", err)
+			}
+			got := make(map[string]string)
+			for _, path := range changed {
+				got[path] = string(patched[path])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyDiff() changed %q, want %q", got, tt.want)
+			}
+			if string(files["a.md"]) != "one\ntwo\nthree\nfour\nfive\nsix\n" {
+				t.Error("applyDiff() modified its input")
+			}
+		})
+	}
+
+	for _, bad := range []string{
+		"--- a/a.md\n+++ b/a.md\n@@ -1 +1 @@\n-seven\n+7\n",                       // Context not found
+		"--- a/missing.md\n+++ b/missing.md\n@@ -1 +1 @@\n-a\n+b\n",               // No such file
+		"--- /dev/null\n+++ b/a.md\n@@ -0,0 +1 @@\n+again\n",                      // Already exists
+		"--- a/a.md\n+++ b/a.md\n@@ -5 +5 @@\n-five\n+5\n@@ -1 +1 @@\n-one\n+1\n", // Hunks out of order
+	} {
+		if _, _, err := applyDiff(files, bad); err == nil {
+			t.Errorf("applyDiff(%q) error = nil, want error", strings.SplitN(bad, "\n", 2)[0])
+		}
+	}
+}
+
+func TestMergeFixes(t *testing.T) {
+	link := func(line int, old, new string) Fix {
+		return Fix{Title: "link", File: "f.md", Line: line, Old: old, New: new}
+	}
+	tests := []struct {
+		name        string
+		content     string
+		fixes       []Fix
+		want        string
+		wantSkipped int
+	}{
+		{
+			name:    "same line",
+			content: "# T\n\nSee [x](a.md) and [y](a.md).\n",
+			fixes:   []Fix{link(3, "](a.md)", "](b.md)"), link(3, "](a.md)", "](b.md)")},
+			want:    "# T\n\nSee [x](b.md) and [y](b.md).\n",
+		},
+		{
+			name:    "duplicate merged",
+			content: "[x](a.md)\n",
+			fixes:   []Fix{link(1, "](a.md)", "](b.md)"), link(1, "](a.md)", "](b.md)")},
+			want:    "[x](b.md)\n",
+		},
+		{
+			name:        "overlap skipped",
+			content:     "[x]({{NAME}}.md)\n",
+			fixes:       []Fix{link(1, "]({{NAME}}.md)", "](x.md)"), {Line: 1, Old: "{{NAME}}", New: "proj"}},
+			want:        "[x](x.md)\n",
+			wantSkipped: 1,
+		},
+		{
+			name:    "column",
+			content: "Use `{{X}}` for {{X}}\n",
+			fixes:   []Fix{{Line: 1, Column: 17, Old: "{{X}}", New: "v"}},
+			want:    "Use `{{X}}` for v\n",
+		},
+		{
+			name:    "insert and replace",
+			content: "# T\n\nbody\n",
+			fixes:   []Fix{{Line: 3, New: "> crumb\n"}, {Line: 3, Old: "body", New: "text"}, {Line: 3, New: "> crumb\n"}},
+			want:    "# T\n\n> crumb\n\ntext\n",
+		},
+		{
+			name:    "append without trailing newline",
+			content: "a\nb",
+			fixes:   []Fix{{Line: 3, New: "c"}},
+			want:    "a\nb\nc",
+		},
+		{
+			name:    "replace last line without trailing newline",
+			content: "a\nb",
+			fixes:   []Fix{{Line: 2, Old: "b", New: "B"}},
+			want:    "a\nB",
+		},
+		{
+			name:        "stale",
+			content:     "a\n",
+			fixes:       []Fix{link(1, "](a.md)", "](b.md)"), link(5, "a", "b"), {Line: 7, New: "x"}},
+			want:        "a\n",
+			wantSkipped: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, applied, skipped := mergeFixes([]byte(tt.content), tt.fixes)
+			if len(skipped) != tt.wantSkipped || len(applied)+len(skipped) != len(tt.fixes) {
+				t.Errorf("mergeFixes() applied %d, skipped %d, want %d skipped", len(applied), len(skipped), tt.wantSkipped)
+			}
+
+			got := tt.content
+			if diff := formatDiff("f.md", ops); diff != "" {
+				patches, err := ParsePatch(diff)
+				if err != nil {
+					t.Fatalf("ParsePatch() error = %v\n%s", err, diff)
+				}
+				files, _, err := applyPatches(map[string][]byte{"f.md": []byte(tt.content)}, patches)
+				if err != nil {
+					t.Fatalf("applyPatches() error = %v\n%s", err, diff)
+				}
+				got = string(files["f.md"])
+			}
+			if got != tt.want {
+				t.Errorf("patched content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	content := strings.Join(lines, "\n") + "\n"
+
+	// Lines 2 and 9 are six unchanged lines apart and share a hunk; line 17 doesn't
+	ops, _, _ := mergeFixes([]byte(content), []Fix{
+		{Line: 2, Old: "line", New: "LINE"},
+		{Line: 9, Old: "line", New: "LINE"},
+		{Line: 17, Old: "line", New: "LINE"},
+	})
+	diff := formatDiff("docs/my notes.md", ops)
+
+	var headers []string
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff ") || strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "@@") {
+			headers = append(headers, line)
+		}
+	}
+	want := []string{
+		"diff --git a/docs/my notes.md b/docs/my notes.md",
+		"--- a/docs/my notes.md",
+		"+++ b/docs/my notes.md",
+		"@@ -1,12 +1,12 @@",
+		"@@ -14,7 +14,7 @@",
+	}
+	if !reflect.DeepEqual(headers, want) {
+		t.Errorf("diff headers = %q, want %q\n%s", headers, want, diff)
+	}
+
+	if diff := formatDiff("a.md", []diffOp{{kind: ' ', text: "same"}}); diff != "" {
+		t.Errorf("formatDiff(unchanged) = %q, want empty", diff)
+	}
+}
+
+func TestFixPatch(t *testing.T) {
+	files := map[string]string{
+		"README.md":                           "# Repo\n\n[Guide](docs/GUIDE.md) and [Setup](docs/setup.md)\n\nWelcome to {{PROJECT_TITLE}} by {{GITHUB_USER}}, not `{{PROJECT_TITLE}}`.\n",
+		"docs/GUIDE.md":                       "# Guide\n\n- [Setup](guide/setup.md)\n\nMore below.\n",
+		"docs/guide/setup.md":                 "# Setup\n\n> Part of [Guide](../GUIDE.md)\n\nSteps.\n",
+		"docs/guide/deploy.md":                "# Deploy\n\n> Part of [Guide](../GUIDE.md)\n\nShip it.\n",
+		"docs/guide/extras.md":                "# Extras\n\nMore.",
+		"genesis/START-HERE.md":               "# Start\n\nName it {{PROJECT_TITLE}}.\n\ncp genesis/templates/index-template.md index.md\n",
+		"genesis/CHECKLIST.md":                "# Checklist\n",
+		"genesis/templates/index-template.md": "# Index\n",
+	}
+	dir := setupGitRepo(t, files)
+	chdir(t, dir)
+
+	config := DefaultConfig()
+	config.RepoRoot = dir
+	config.EntryPoints = nil
+	validate := func() (*Validator, *ValidationResult) {
+		t.Helper()
+		v := NewValidator(config)
+		result, err := v.Validate()
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		return v, result
+	}
+
+	v, result := validate()
+	patch, err := v.FixPatch(context.Background(), result, map[string]string{"PROJECT_TITLE": "Demo", "GITHUB_USER": "octo"})
+	if err != nil {
+		t.Fatalf("FixPatch() error = %v", err)
+	}
+	if want := []string{"README.md", "docs/GUIDE.md", "docs/guide/extras.md"}; !reflect.DeepEqual(patch.Files, want) {
+		t.Errorf("FixPatch() files = %v, want %v\n%s", patch.Files, want, patch.Patch)
+	}
+	if len(patch.Skipped) != 0 {
+		t.Errorf("FixPatch() skipped %+v", patch.Skipped)
+	}
+
+	name := filepath.Join(t.TempDir(), "fixes.patch")
+	if err := os.WriteFile(name, []byte(patch.Patch), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "apply", "--check", name)
+	runGit(t, dir, "apply", name)
+
+	want := map[string]string{
+		"README.md":             "# Repo\n\n[Guide](docs/GUIDE.md) and [Setup](docs/guide/setup.md)\n\nWelcome to Demo by octo, not `{{PROJECT_TITLE}}`.\n",
+		"docs/GUIDE.md":         "# Guide\n\n- [Setup](guide/setup.md)\n- [Deploy](guide/deploy.md)\n- [Extras](guide/extras.md)\n\nMore below.\n",
+		"docs/guide/extras.md":  "# Extras\n\n> Part of [Guide](../GUIDE.md)\n\nMore.",
+		"genesis/START-HERE.md": files["genesis/START-HERE.md"],
+	}
+	for file, content := range want {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != content {
+			t.Errorf("%s after git apply = %q, want %q", file, data, content)
+		}
+	}
+
+	_, after := validate()
+	if len(after.BrokenLinks) != 0 {
+		t.Errorf("broken links after git apply = %+v", after.BrokenLinks)
+	}
+	for _, inc := range after.Inconsistencies {
+		if inc.Type == "unlinked_child" || inc.Type == "missing_breadcrumb" {
+			t.Errorf("finding after git apply: %s %s: %s", inc.Type, inc.File, inc.Description)
+		}
+	}
+}
+
+func TestLoadVariables(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "vars.json", `{"PROJECT_NAME": "one-pager", "PHASE_1_AI": "Claude"}`)
+	writeTestFile(t, dir, "braces.json", `{"{{PROJECT_NAME}}": "one-pager"}`)
+	writeTestFile(t, dir, "nested.json", `{"PROJECT_NAME": {"value": "one-pager"}}`)
+
+	vars, err := LoadVariables(filepath.Join(dir, "vars.json"))
+	if err != nil {
+		t.Fatalf("LoadVariables() error = %v", err)
+	}
+	if want := map[string]string{"PROJECT_NAME": "one-pager", "PHASE_1_AI": "Claude"}; !reflect.DeepEqual(vars, want) {
+		t.Errorf("LoadVariables() = %v, want %v", vars, want)
+	}
+	for _, bad := range []string{"braces.json", "nested.json", "missing.json"} {
+		if _, err := LoadVariables(filepath.Join(dir, bad)); err == nil {
+			t.Errorf("LoadVariables(%s) error = nil", bad)
+		}
+	}
+}
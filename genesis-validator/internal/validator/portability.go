@@ -0,0 +1,256 @@
+package validator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// illegalNameChars are rejected in file names by Windows
+const illegalNameChars = `<>:"|?*\`
+
+// reservedNames are device names Windows refuses as file names, with or
+// without an extension
+var reservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// trackedPaths holds the exact-case names of git-tracked files and their
+// directories, and an index of them by lower-case name
+type trackedPaths struct {
+	exact  pathSet
+	folded map[string][]string // Lower-case path -> exact-case paths
+}
+
+// newTrackedPaths indexes tracked file paths
+func newTrackedPaths(files []string) *trackedPaths {
+	t := &trackedPaths{exact: newPathSet(files), folded: make(map[string][]string)}
+	for p := range t.exact {
+		lower := strings.ToLower(p)
+		t.folded[lower] = append(t.folded[lower], p)
+	}
+	for _, paths := range t.folded {
+		sort.Strings(paths)
+	}
+	return t
+}
+
+// caseVariant returns the tracked path that p matches only when case is
+// ignored, or "" when p is tracked exactly or not at all
+func (t *trackedPaths) caseVariant(p string) string {
+	p = cleanSlash(p)
+	if t.exact[p] {
+		return ""
+	}
+	if paths := t.folded[strings.ToLower(p)]; len(paths) > 0 {
+		return paths[0]
+	}
+	return ""
+}
+
+// validatePortability checks that links and references use the exact case of
+// git-tracked files, since macOS and Windows resolve any case but Linux does
+// not, and that tracked names are portable. It is skipped outside a git
+// working tree.
+func (v *Validator) validatePortability(result *ValidationResult, mdFiles []string) {
+	repo := v.ws.Git()
+	if repo == nil {
+		return // Not on disk
+	}
+	files, err := repo.StagedFiles()
+	if err != nil {
+		return // Not a git repository
+	}
+	tracked := newTrackedPaths(files)
+	result.markChecked("case_mismatch")
+
+	// Report case-variant links as case mismatches with the exact-case fix,
+	// not as the generic broken links they are on case-sensitive filesystems
+	mismatches, replaced := v.linkValidator.findCaseMismatches(tracked, mdFiles)
+	replaceBrokenLinks(result, replaced)
+	addBrokenLinks(result, mismatches)
+
+	result.Inconsistencies = append(result.Inconsistencies, v.referenceCaseMismatches(tracked, result)...)
+	result.Inconsistencies = append(result.Inconsistencies, caseCollisions(tracked)...)
+	result.Inconsistencies = append(result.Inconsistencies, illegalFileNames(files)...)
+}
+
+// caseFix builds a Fix that rewrites a relative link to the exact-case
+// tracked path
+func caseFix(sourceFile string, link linkInfo, variant string) *Fix {
+	if strings.Contains(link.url, "://") {
+		return nil
+	}
+	rel := relativeLink(sourceFile, variant)
+	if idx := strings.Index(link.url, "#"); idx != -1 {
+		rel += link.url[idx:]
+	}
+	return linkFix(sourceFile, link, rel, "Change link to "+rel)
+}
+
+// findCaseMismatches returns links whose target differs in case from a
+// tracked file: on a filesystem that ignores case they resolve, and on one
+// that doesn't they are broken. It also returns the broken links that the
+// mismatches replace.
+func (lv *LinkValidator) findCaseMismatches(tracked *trackedPaths, mdFiles []string) (mismatches, replaced []BrokenLink) {
+	for _, file := range mdFiles {
+		links, err := lv.extractLinks(file)
+		if err != nil {
+			continue // Skip files we can't read
+		}
+
+		for _, link := range links {
+			targets := lv.linkTargets(file, link.url)
+			if len(targets) == 0 {
+				continue
+			}
+
+			variant := ""
+			for _, target := range targets {
+				if tracked.exact[target] {
+					variant = ""
+					break
+				}
+				if variant == "" {
+					variant = tracked.caseVariant(target)
+				}
+			}
+			if variant == "" {
+				continue
+			}
+
+			if broken := lv.validateLink(file, link); broken != nil {
+				replaced = append(replaced, *broken)
+			}
+			mismatches = append(mismatches, BrokenLink{
+				SourceFile: file,
+				Line:       link.line,
+				LinkText:   link.text,
+				LinkURL:    link.url,
+				Reason:     fmt.Sprintf("Path differs in case from tracked %s and breaks on case-sensitive filesystems", variant),
+				Fix:        caseFix(file, link, variant),
+				rule:       "case_mismatch",
+			})
+		}
+	}
+	return mismatches, replaced
+}
+
+// replaceBrokenLinks drops the given broken links from a result, each once
+func replaceBrokenLinks(result *ValidationResult, links []BrokenLink) {
+	drop := make(map[findingKey]int)
+	for _, link := range links {
+		drop[keyOf(link.findingType(), link.SourceFile, link.Line, link.Reason)]++
+	}
+	result.removeFindings(func(inc Inconsistency) bool {
+		key := keyOf(inc.Type, inc.File, inc.Line, inc.Description)
+		if drop[key] == 0 {
+			return false
+		}
+		drop[key]--
+		return true
+	})
+}
+
+// referenceCaseMismatches suggests the exact-case name for template and
+// baseline references that only match a tracked file when case is ignored
+func (v *Validator) referenceCaseMismatches(tracked *trackedPaths, result *ValidationResult) []Inconsistency {
+	var findings []Inconsistency
+	for refPath, refs := range result.References {
+		candidates := []string{
+			path.Join(filepath.ToSlash(v.config.GenesisRoot), refPath),
+			path.Join(filepath.ToSlash(v.config.BaselineDir), refPath),
+			refPath,
+		}
+
+		variant := ""
+		for _, candidate := range candidates {
+			if tracked.exact[cleanSlash(candidate)] {
+				variant = ""
+				break
+			}
+			if variant == "" {
+				variant = tracked.caseVariant(candidate)
+			}
+		}
+		if variant == "" {
+			continue
+		}
+
+		for _, ref := range refs {
+			findings = append(findings, Inconsistency{
+				Type:        "case_mismatch",
+				File:        ref.Doc,
+				Line:        ref.Line,
+				Description: fmt.Sprintf("Reference %s differs in case from tracked %s", refPath, variant),
+				Location:    ref.String(),
+			})
+		}
+	}
+	return findings
+}
+
+// caseCollisions reports tracked paths that differ only in case. Only one
+// of them can exist in a checkout on macOS or Windows.
+func caseCollisions(tracked *trackedPaths) []Inconsistency {
+	var findings []Inconsistency
+	for _, paths := range tracked.folded {
+		if len(paths) < 2 {
+			continue
+		}
+		findings = append(findings, Inconsistency{
+			Type:        "case_collision",
+			File:        paths[0],
+			Description: fmt.Sprintf("Tracked paths differ only in case and collide on case-insensitive filesystems: %s", strings.Join(paths, ", ")),
+			Location:    paths[0],
+		})
+	}
+	return findings
+}
+
+// illegalFileNames reports tracked files with a path component that cannot
+// be created on Windows
+func illegalFileNames(files []string) []Inconsistency {
+	var findings []Inconsistency
+	for _, file := range files {
+		for _, name := range strings.Split(file, "/") {
+			if reason := illegalNameReason(name); reason != "" {
+				findings = append(findings, Inconsistency{
+					Type:        "illegal_filename",
+					File:        file,
+					Description: fmt.Sprintf("Name %q %s", name, reason),
+					Location:    file,
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+// illegalNameReason explains why a file name is not portable, or returns ""
+func illegalNameReason(name string) string {
+	for _, r := range name {
+		if r < 0x20 {
+			return "contains a control character"
+		}
+		if strings.ContainsRune(illegalNameChars, r) {
+			return fmt.Sprintf("contains %q, which is illegal on Windows", r)
+		}
+	}
+	if strings.HasSuffix(name, " ") || strings.HasSuffix(name, ".") {
+		return "ends in a space or dot, which Windows strips"
+	}
+	base := strings.ToUpper(name)
+	if idx := strings.Index(base, "."); idx != -1 {
+		base = base[:idx]
+	}
+	if reservedNames[base] {
+		return "is a reserved device name on Windows"
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestFindCaseMismatches(t *testing.T) {
+	tracked := newTrackedPaths([]string{"README.md", "docs/anti-patterns.md", "docs/Guide.md"})
+	files := map[string]string{
+		"README.md": "# Repo\n\n[a](Docs/anti-patterns.md)\n[b](docs/anti-patterns.md)\n[c](docs/guide.md#intro)\n" +
+			"[d](https://github.com/bordenet/genesis/blob/main/docs/ANTI-patterns.md)\n[e](docs/missing.md)\n",
+	}
+
+	tmpDir := t.TempDir()
+	for name, content := range files {
+		writeTestFile(t, tmpDir, name, content)
+	}
+	chdir(t, tmpDir)
+
+	// Resolve paths the way macOS and Windows do
+	lv := NewLinkValidator(DefaultConfig())
+	lv.exists = func(p string) bool { return tracked.exact[cleanSlash(p)] || tracked.caseVariant(p) != "" }
+
+	var got, fixes []string
+	mismatches, replaced := lv.findCaseMismatches(tracked, []string{"README.md"})
+	if len(replaced) != 0 {
+		t.Errorf("findCaseMismatches() replaced = %+v, want none when case is ignored", replaced)
+	}
+	for _, link := range mismatches {
+		got = append(got, fmt.Sprintf("%d: %s", link.Line, link.Reason))
+		if link.Fix != nil {
+			fixes = append(fixes, fmt.Sprintf("%d: %s", link.Line, link.Fix.New))
+		}
+	}
+	want := []string{
+		"3: Path differs in case from tracked docs/anti-patterns.md and breaks on case-sensitive filesystems",
+		"5: Path differs in case from tracked docs/Guide.md and breaks on case-sensitive filesystems",
+		"6: Path differs in case from tracked docs/anti-patterns.md and breaks on case-sensitive filesystems",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findCaseMismatches() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// GitHub URLs get no fix, since rewriting them as relative links changes more than case
+	wantFixes := []string{"3: ](docs/anti-patterns.md)", "5: ](docs/Guide.md#intro)"}
+	if !reflect.DeepEqual(fixes, wantFixes) {
+		t.Errorf("findCaseMismatches() fixes = %v, want %v", fixes, wantFixes)
+	}
+}
+
+func TestReferenceCaseMismatches(t *testing.T) {
+	tracked := newTrackedPaths([]string{"genesis/templates/web/index-template.html", "genesis/examples/hello-world/src/app.js"})
+	v := NewValidator(DefaultConfig())
+	result := newValidationResult()
+	result.References["templates/Web/index-template.html"] = []Reference{{Path: "templates/Web/index-template.html", Doc: "genesis/START-HERE.md", Line: 12}}
+	result.References["src/app.js"] = []Reference{{Path: "src/app.js", Doc: "genesis/steps/01-setup.md", Line: 4}}
+
+	findings := v.referenceCaseMismatches(tracked, result)
+	if len(findings) != 1 {
+		t.Fatalf("findings = %+v, want one", findings)
+	}
+	want := "Reference templates/Web/index-template.html differs in case from tracked genesis/templates/web/index-template.html"
+	if findings[0].Description != want || findings[0].Location != "genesis/START-HERE.md:12" {
+		t.Errorf("finding = %+v, want %q at genesis/START-HERE.md:12", findings[0], want)
+	}
+}
+
+func TestValidate_Portability(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{
+		"README.md":             "# Repo\n",
+		"docs/Notes.md":         "# Notes\n",
+		"docs/notes.md":         "# notes\n",
+		"docs/a:b.md":           "# Colon\n",
+		"docs/aux.txt":          "device\n",
+		"docs/trailing. ":       "dot\n",
+		"docs/portable.md":      "# Fine\n",
+		"genesis/START-HERE.md": "# Start\n",
+		"genesis/CHECKLIST.md":  "# Checklist\n",
+	})
+	chdir(t, dir)
+
+	config := DefaultConfig()
+	config.EntryPoints = nil
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var got []string
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "case_collision" || inc.Type == "illegal_filename" {
+			got = append(got, inc.Type+" "+inc.File+": "+inc.Description)
+		}
+	}
+	want := []string{
+		`case_collision docs/Notes.md: Tracked paths differ only in case and collide on case-insensitive filesystems: docs/Notes.md, docs/notes.md`,
+		`illegal_filename docs/a:b.md: Name "a:b.md" contains ':', which is illegal on Windows`,
+		`illegal_filename docs/aux.txt: Name "aux.txt" is a reserved device name on Windows`,
+		`illegal_filename docs/trailing. : Name "trailing. " ends in a space or dot, which Windows strips`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("portability findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidate_CaseMismatchInGitRepo(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{
+		"docs/guide.md":         "# Guide\n\n## Intro\n",
+		"genesis/START-HERE.md": "# Start\n",
+		"genesis/CHECKLIST.md":  "# Checklist\n",
+	})
+	writeTestFile(t, dir, "README.md", strings.Join([]string{
+		"# Repo",
+		"",
+		"[Guide](docs/Guide.md#intro)",
+		"<!-- genesis-validator-disable-next-line case_mismatch -->",
+		"[Guide](Docs/guide.md)",
+		"[Missing](docs/missing.md)",
+	}, "\n"))
+	runGit(t, dir, "add", "README.md")
+	chdir(t, dir)
+
+	config := DefaultConfig()
+	config.EntryPoints = nil
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var got []string
+	for _, inc := range result.Inconsistencies {
+		if inc.File == "README.md" {
+			got = append(got, fmt.Sprintf("%s %d: %s", inc.Type, inc.Line, inc.Description))
+		}
+	}
+	want := []string{
+		"case_mismatch 3: Path differs in case from tracked docs/guide.md and breaks on case-sensitive filesystems",
+		"broken_link 6: Relative path not found: docs/missing.md",
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("README.md findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	var fixes []string
+	for _, link := range result.BrokenLinks {
+		if link.Line == 3 && link.Fix != nil {
+			fixes = append(fixes, link.Fix.New)
+		}
+	}
+	if want := []string{"](docs/guide.md#intro)"}; !reflect.DeepEqual(fixes, want) {
+		t.Errorf("case mismatch fixes = %v, want %v", fixes, want)
+	}
+	if result.IsValid() {
+		t.Error("IsValid() = true, want false for a case mismatch")
+	}
+}
@@ -1,10 +1,48 @@
 package validator
 
 import (
+	"embed"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
 )
 
+// promptContextLines is how many lines of source are quoted on each side of
+// a finding
+const promptContextLines = 2
+
+// DefaultPromptProfile is the built-in prompt profile used when none is set
+const DefaultPromptProfile = "default"
+
+// DefaultPromptTokens is the default token budget for a single prompt
+const DefaultPromptTokens = 24000
+
+// promptFiles holds the built-in prompt profiles and the blocks they share
+//
+//go:embed prompts/*.tmpl
+var promptFiles embed.FS
+
+// promptFuncs are the functions available to prompt templates
+var promptFuncs = template.FuncMap{
+	"add":  func(a, b int) int { return a + b },
+	"join": strings.Join,
+}
+
+// PromptProfiles returns the names of the built-in prompt profiles
+func PromptProfiles() []string {
+	entries, _ := promptFiles.ReadDir("prompts")
+	var names []string
+	for _, entry := range entries {
+		if name := strings.TrimSuffix(entry.Name(), ".tmpl"); name != "common" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // PromptGenerator generates LLM prompts for validation issues
 type PromptGenerator struct {
 	config *Config
@@ -15,78 +53,399 @@ func NewPromptGenerator(config *Config) *PromptGenerator {
 	return &PromptGenerator{config: config}
 }
 
-// GeneratePrompt creates a detailed LLM prompt for fixing validation issues
+// PromptData is what a prompt template renders
+type PromptData struct {
+	Profile string // Name of the profile being rendered
+	Part    int    // 1-based number of this prompt when the findings are split
+	Parts   int    // Number of prompts the findings are split into
+	Command string // Command that re-runs the validator
+	Summary PromptSummary
+
+	// Errors, OrphanedFiles and MissingFiles are set in the first part only
+	Errors        []string
+	OrphanedFiles []string
+	MissingFiles  []PromptMissingFile
+
+	Files []PromptFile // Findings in this part, grouped by file
+}
+
+// PromptSummary counts the findings of the whole result, across all parts
+type PromptSummary struct {
+	TemplateFiles   int
+	OrphanedFiles   int
+	MissingFiles    int
+	BrokenLinks     int
+	Inconsistencies int
+	Errors          int
+}
+
+// PromptMissingFile is a missing file and the docs that reference it
+type PromptMissingFile struct {
+	Path         string
+	ReferencedIn []string
+}
+
+// PromptFile is a file and the findings to fix in it
+type PromptFile struct {
+	Path     string
+	Findings []PromptFinding
+}
+
+// PromptFinding is one finding as shown in a prompt, located where the edit
+// belongs
+type PromptFinding struct {
+	Rule     string
+	Severity Severity
+	Line     int // 0 when the finding is about the whole file
+	Message  string
+	Fix      *Fix         // Suggested edit, nil when the fix isn't mechanical
+	Edit     *PromptEdit  // Fix as whole lines, nil when the line can't be read
+	Context  []SourceLine // Lines around Line
+	Fence    string       // Code fence longer than any backtick run in Context and Edit
+}
+
+// PromptEdit is a suggested edit as the line before and after it
+type PromptEdit struct {
+	Old string
+	New string
+}
+
+// SourceLine is a quoted line of source
+type SourceLine struct {
+	Number int
+	Text   string
+	Marked bool // The line the finding is on
+}
+
+// GeneratePrompt renders every finding into a single prompt with the
+// built-in default profile, ignoring the token budget
 func (g *PromptGenerator) GeneratePrompt(result *ValidationResult) string {
 	if result.IsValid() && !result.HasWarnings() {
 		return ""
 	}
 
+	tmpl, err := loadPromptProfile(DefaultPromptProfile, "")
+	if err != nil {
+		panic(err) // Built-in profiles are checked by tests
+	}
+	prompt, err := renderPrompt(tmpl, newPromptData(result, DefaultPromptProfile))
+	if err != nil {
+		panic(err)
+	}
+	return prompt
+}
+
+// GeneratePrompts renders the result with the configured profile, splitting
+// the findings into several prompts when one would exceed the token budget.
+// It returns nil when there is nothing to fix.
+func (g *PromptGenerator) GeneratePrompts(result *ValidationResult) ([]string, error) {
+	if result.IsValid() && !result.HasWarnings() {
+		return nil, nil
+	}
+
+	profile := g.config.PromptProfile
+	if profile == "" {
+		profile = DefaultPromptProfile
+	}
+	tmpl, err := loadPromptProfile(profile, g.config.RepoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	data := newPromptData(result, profile)
+	whole, err := renderPrompt(tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+	if g.config.PromptTokens <= 0 || estimateTokens(whole) <= g.config.PromptTokens {
+		return []string{whole}, nil
+	}
+
+	parts, err := splitPrompt(tmpl, data, g.config.PromptTokens)
+	if err != nil {
+		return nil, err
+	}
+	prompts := make([]string, len(parts))
+	for i, part := range parts {
+		if prompts[i], err = renderPrompt(tmpl, part); err != nil {
+			return nil, err
+		}
+	}
+	return prompts, nil
+}
+
+// loadPromptProfile parses a built-in profile by name, or a template file
+// when the profile is a path, along with the shared blocks. Relative paths
+// are relative to root.
+func loadPromptProfile(profile, root string) (*template.Template, error) {
+	common, err := promptFiles.ReadFile("prompts/common.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	var text []byte
+	if IsPromptFile(profile) {
+		path := profile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, filepath.FromSlash(path))
+		}
+		if text, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read prompt template: %w", err)
+		}
+	} else if text, err = promptFiles.ReadFile("prompts/" + profile + ".tmpl"); err != nil || profile == "common" {
+		return nil, fmt.Errorf("unknown prompt profile %q: want one of %s or a .tmpl file",
+			profile, strings.Join(PromptProfiles(), ", "))
+	}
+
+	tmpl, err := template.New(profile).Funcs(promptFuncs).Parse(string(common))
+	if err == nil {
+		tmpl, err = tmpl.Parse(string(text))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid prompt template %s: %w", profile, err)
+	}
+	return tmpl, nil
+}
+
+// IsPromptFile reports whether a prompt profile names a template file rather
+// than a built-in one
+func IsPromptFile(profile string) bool {
+	return strings.HasSuffix(profile, ".tmpl") || strings.ContainsAny(profile, `/\`)
+}
+
+// renderPrompt executes a prompt template
+func renderPrompt(tmpl *template.Template, data *PromptData) (string, error) {
 	var prompt strings.Builder
+	if err := tmpl.Execute(&prompt, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+	return prompt.String(), nil
+}
+
+// estimateTokens approximates the tokens in a prompt at four bytes a token,
+// which is close for English markdown with most tokenizers
+func estimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// newPromptData builds the data for a single prompt holding every finding
+func newPromptData(result *ValidationResult, profile string) *PromptData {
+	data := &PromptData{
+		Profile: profile,
+		Part:    1,
+		Parts:   1,
+		Command: "./genesis-validator/bin/genesis-validator",
+		Summary: PromptSummary{
+			TemplateFiles:   len(result.TemplateFiles),
+			OrphanedFiles:   len(result.OrphanedFiles),
+			MissingFiles:    len(result.MissingFiles),
+			BrokenLinks:     len(result.BrokenLinks),
+			Inconsistencies: len(result.Inconsistencies),
+			Errors:          len(result.Errors),
+		},
+		OrphanedFiles: result.OrphanedFiles,
+		Files:         groupPromptFindings(result),
+	}
+	for _, err := range result.Errors {
+		data.Errors = append(data.Errors, err.Error())
+	}
+	for _, file := range result.MissingFiles {
+		data.MissingFiles = append(data.MissingFiles, PromptMissingFile{Path: file, ReferencedIn: result.ReferencedFiles[file]})
+	}
+	return data
+}
 
-	prompt.WriteString("# 🚨 Genesis Validation Failed\n\n")
-	prompt.WriteString("The Genesis template validator has detected inconsistencies that need to be fixed.\n\n")
+// splitPrompt divides the findings into parts whose prompts fit the budget,
+// keeping a file's findings together unless they alone exceed it. A part
+// always gets at least one finding, so a finding larger than the budget
+// gets a prompt of its own.
+func splitPrompt(tmpl *template.Template, data *PromptData, budget int) ([]*PromptData, error) {
+	tokens := func(d *PromptData) (int, error) {
+		prompt, err := renderPrompt(tmpl, d)
+		return estimateTokens(prompt), err
+	}
 
-	prompt.WriteString("## 📊 Validation Summary\n\n")
-	fmt.Fprintf(&prompt, "- **Template files found**: %d\n", len(result.TemplateFiles))
-	fmt.Fprintf(&prompt, "- **Orphaned files**: %d\n", len(result.OrphanedFiles))
-	fmt.Fprintf(&prompt, "- **Missing files**: %d\n", len(result.MissingFiles))
-	fmt.Fprintf(&prompt, "- **Inconsistencies**: %d\n", len(result.Inconsistencies))
-	fmt.Fprintf(&prompt, "- **Errors**: %d\n\n", len(result.Errors))
+	first := *data
+	first.Files = nil
+	rest := first
+	rest.Errors, rest.OrphanedFiles, rest.MissingFiles = nil, nil, nil
 
-	if len(result.Errors) > 0 {
-		prompt.WriteString("## ❌ Critical Errors\n\n")
-		for i, err := range result.Errors {
-			fmt.Fprintf(&prompt, "%d. %s\n", i+1, err.Error())
+	firstOverhead, err := tokens(&first)
+	if err != nil {
+		return nil, err
+	}
+	restOverhead, err := tokens(&rest)
+	if err != nil {
+		return nil, err
+	}
+	cost := func(f PromptFile) (int, error) {
+		d := rest
+		d.Files = []PromptFile{f}
+		n, err := tokens(&d)
+		return n - restOverhead, err
+	}
+
+	// Files too large for any part are split into one unit per finding
+	var units []PromptFile
+	var costs []int
+	for _, file := range data.Files {
+		n, err := cost(file)
+		if err != nil {
+			return nil, err
+		}
+		if n <= budget-restOverhead || len(file.Findings) == 1 {
+			units, costs = append(units, file), append(costs, n)
+			continue
+		}
+		for _, finding := range file.Findings {
+			unit := PromptFile{Path: file.Path, Findings: []PromptFinding{finding}}
+			if n, err = cost(unit); err != nil {
+				return nil, err
+			}
+			units, costs = append(units, unit), append(costs, n)
 		}
-		prompt.WriteString("\n")
 	}
 
-	if len(result.OrphanedFiles) > 0 {
-		prompt.WriteString("## 🔍 Orphaned Template Files\n\n")
-		prompt.WriteString("These template files exist but are NOT referenced in START-HERE.md:\n\n")
-		for i, file := range result.OrphanedFiles {
-			fmt.Fprintf(&prompt, "%d. `%s`\n", i+1, file)
+	parts := []*PromptData{&first}
+	used := firstOverhead
+	for i, unit := range units {
+		part := parts[len(parts)-1]
+		if used+costs[i] > budget && len(part.Files) > 0 {
+			next := rest
+			part = &next
+			parts = append(parts, part)
+			used = restOverhead
+		}
+		// Findings of one file split across units stay under one heading
+		if last := len(part.Files) - 1; last >= 0 && part.Files[last].Path == unit.Path {
+			part.Files[last].Findings = append(part.Files[last].Findings, unit.Findings...)
+		} else {
+			part.Files = append(part.Files, unit)
 		}
-		prompt.WriteString("\n**Action Required**: For each orphaned file, decide:\n")
-		prompt.WriteString("- **Option 1**: Add to START-HERE.md Section 3 (if MANDATORY or RECOMMENDED)\n")
-		prompt.WriteString("- **Option 2**: Add to START-HERE.md Section 3.7 (if OPTIONAL)\n")
-		prompt.WriteString("- **Option 3**: Remove the file (if obsolete)\n\n")
+		used += costs[i]
+	}
+
+	for i, part := range parts {
+		part.Part, part.Parts = i+1, len(parts)
 	}
+	return parts, nil
+}
 
-	if len(result.MissingFiles) > 0 {
-		prompt.WriteString("## ⚠️ Missing Template Files\n\n")
-		prompt.WriteString("These files are referenced in documentation but DO NOT exist:\n\n")
-		for i, file := range result.MissingFiles {
-			docs := result.ReferencedFiles[file]
-			fmt.Fprintf(&prompt, "%d. `%s`\n", i+1, file)
-			fmt.Fprintf(&prompt, "   Referenced in: %s\n", strings.Join(docs, ", "))
+// groupPromptFindings collects every finding in the result, grouped by file
+// and sorted by line. Missing files are listed at each reference to them,
+// since that is where the fix is made when the file shouldn't exist.
+// Findings only in the typed lists, as in hand-built results, are included.
+func groupPromptFindings(result *ValidationResult) []PromptFile {
+	byFile := make(map[string][]PromptFinding)
+	seen := make(map[findingKey]bool)
+	add := func(file string, f PromptFinding) {
+		f.Severity = SeverityWarning
+		if errorRules[f.Rule] {
+			f.Severity = SeverityError
+		}
+		byFile[file] = append(byFile[file], f)
+	}
+	addMissing := func(file, description string) {
+		refs := result.References[file]
+		if len(refs) == 0 {
+			add(file, PromptFinding{Rule: "missing_file", Message: description})
+			return
+		}
+		for _, ref := range refs {
+			add(ref.Doc, PromptFinding{Rule: "missing_file", Line: ref.Line,
+				Message: "References " + file + ", which does not exist"})
 		}
-		prompt.WriteString("\n**Action Required**: For each missing file:\n")
-		prompt.WriteString("- **Option 1**: Create the template file\n")
-		prompt.WriteString("- **Option 2**: Remove references from documentation (if obsolete)\n\n")
 	}
 
-	// Note: Doc consistency check was removed since CHECKLIST.md is a high-level
-	// verification document. START-HERE.md is the single source of truth for templates.
+	fixes := make(map[findingKey]*Fix)
+	for _, link := range result.BrokenLinks {
+		if link.Fix != nil {
+			fixes[keyOf(link.findingType(), link.SourceFile, link.Line, link.Reason)] = link.Fix
+		}
+	}
 
-	prompt.WriteString("## 🎯 Recommended Actions\n\n")
-	prompt.WriteString("1. **Review all orphaned files** - Add to START-HERE.md or remove\n")
-	prompt.WriteString("2. **Fix missing files** - Create templates or remove references\n")
-	prompt.WriteString("3. **Run validator again** - Verify all issues are resolved\n")
-	prompt.WriteString("4. **Update CHANGELOG.md** - Document what was fixed\n\n")
+	for _, inc := range result.Inconsistencies {
+		key := keyOf(inc.Type, inc.File, inc.Line, inc.Description)
+		if seen[key] && (inc.Type == "orphaned_file" || inc.Type == "missing_file") {
+			continue
+		}
+		seen[key] = true
+		if inc.Type == "missing_file" {
+			addMissing(inc.File, inc.Description)
+			continue
+		}
+		add(inc.File, PromptFinding{Rule: inc.Type, Line: inc.Line, Message: inc.Description, Fix: fixes[key]})
+	}
 
-	prompt.WriteString("## 📝 Example Fix\n\n")
-	prompt.WriteString("```bash\n")
-	prompt.WriteString("# For orphaned file: templates/web-app/new-feature-template.js\n")
-	prompt.WriteString("# Add to START-HERE.md Section 3.2:\n")
-	prompt.WriteString("cp genesis/templates/web-app/new-feature-template.js js/new-feature.js\n")
-	prompt.WriteString("```\n\n")
+	for _, link := range result.BrokenLinks {
+		if !seen[keyOf(link.findingType(), link.SourceFile, link.Line, link.Reason)] {
+			add(link.SourceFile, PromptFinding{Rule: link.findingType(), Line: link.Line, Message: link.Reason, Fix: link.Fix})
+		}
+	}
+	for _, file := range result.OrphanedFiles {
+		if !seen[keyOf("orphaned_file", file, 0, "")] {
+			add(file, PromptFinding{Rule: "orphaned_file", Message: "Not referenced in any documentation"})
+		}
+	}
+	for _, file := range result.MissingFiles {
+		if !seen[keyOf("missing_file", file, 0, "")] {
+			addMissing(file, "Referenced in documentation but file does not exist")
+		}
+	}
 
-	prompt.WriteString("---\n\n")
-	prompt.WriteString("**Run this command to validate again**:\n")
-	prompt.WriteString("```bash\n")
-	prompt.WriteString("./genesis-validator/bin/genesis-validator\n")
-	prompt.WriteString("```\n")
+	files := make([]PromptFile, 0, len(byFile))
+	for path, findings := range byFile {
+		sort.SliceStable(findings, func(i, j int) bool {
+			if findings[i].Line != findings[j].Line {
+				return findings[i].Line < findings[j].Line
+			}
+			return findings[i].Rule < findings[j].Rule
+		})
+		addSourceContext(result, path, findings)
+		files = append(files, PromptFile{Path: path, Findings: findings})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// addSourceContext quotes the lines around each finding in a file and
+// renders its fix as whole lines
+func addSourceContext(result *ValidationResult, path string, findings []PromptFinding) {
+	var lines []string
+	for _, f := range findings {
+		if f.Line > 0 || f.Fix != nil {
+			if data, err := result.source(path); err == nil {
+				lines = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+			}
+			break
+		}
+	}
+
+	for i := range findings {
+		f := &findings[i]
+		var quoted []string
+		if f.Line > 0 && f.Line <= len(lines) {
+			for n := max(f.Line-promptContextLines, 1); n <= min(f.Line+promptContextLines, len(lines)); n++ {
+				f.Context = append(f.Context, SourceLine{Number: n, Text: lines[n-1], Marked: n == f.Line})
+				quoted = append(quoted, lines[n-1])
+			}
+		}
+		if fix := f.Fix; fix != nil && fix.Line > 0 && fix.Line <= len(lines) && strings.Contains(lines[fix.Line-1], fix.Old) {
+			old := lines[fix.Line-1]
+			f.Edit = &PromptEdit{Old: old, New: strings.Replace(old, fix.Old, fix.New, 1)}
+			quoted = append(quoted, f.Edit.Old, f.Edit.New)
+		}
+		f.Fence = codeFence(quoted)
+	}
+}
 
-	return prompt.String()
+// codeFence returns a fence longer than any backtick run in lines
+func codeFence(lines []string) string {
+	fence := "```"
+	for _, line := range lines {
+		for strings.Contains(line, fence) {
+			fence += "`"
+		}
+	}
+	return fence
 }
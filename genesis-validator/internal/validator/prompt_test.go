@@ -2,6 +2,8 @@ package validator
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -175,3 +177,207 @@ func TestGeneratePrompt_WithInconsistencies(t *testing.T) {
 		t.Error("Prompt should contain Validation Summary")
 	}
 }
+
+func TestGeneratePrompt_FindingsByFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "README.md", "# Repo\n\nIntro.\n\nSee [the guide](guide.md) first.\n\nEnd.\n")
+	writeTestFile(t, tmpDir, "docs/guide.md", "# Guide\n")
+	writeTestFile(t, tmpDir, "docs/notes.md", "# Notes\n\n[Gone](nowhere.md)\n")
+	writeTestFile(t, tmpDir, "genesis/START-HERE.md", "# Start\n\ncp genesis/templates/gone-template.md gone.md\n")
+	writeTestFile(t, tmpDir, "genesis/CHECKLIST.md", "# Checklist\n")
+	writeTestFile(t, tmpDir, "genesis/templates/x-template.md", "# X\n")
+
+	config := DefaultConfig()
+	config.RepoRoot = tmpDir
+	config.EntryPoints = nil
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	prompt := NewPromptGenerator(config).GeneratePrompt(result)
+
+	expectedContent := []string{
+		"Findings by File",
+		"### `README.md`",
+		"- **broken_link** (error, line 5): Relative path not found: guide.md",
+		">    5 | See [the guide](guide.md) first.",
+		"     3 | Intro.",
+		"Suggested edit (line 5): Change link to docs/guide.md",
+		"  -See [the guide](guide.md) first.\n  +See [the guide](docs/guide.md) first.",
+		"### `docs/notes.md`",
+		"- **broken_link** (error, line 3): Relative path not found: nowhere.md",
+		"### `genesis/START-HERE.md`",
+		"- **missing_file** (error, line 3): References templates/gone-template.md, which does not exist",
+		"### `templates/x-template.md`",
+		"- **orphaned_file** (error):",
+	}
+	for _, content := range expectedContent {
+		if !strings.Contains(prompt, content) {
+			t.Errorf("Prompt missing expected content: %q\n%s", content, prompt)
+		}
+	}
+
+	// No mechanical fix exists for a target with no similarly named file
+	notes := prompt[strings.Index(prompt, "### `docs/notes.md`"):]
+	notes = notes[:strings.Index(notes, "### `genesis/")]
+	if strings.Contains(notes, "Suggested edit") {
+		t.Errorf("docs/notes.md got a suggested edit:\n%s", notes)
+	}
+}
+
+func TestGeneratePrompt_BrokenLinksOnly(t *testing.T) {
+	generator := NewPromptGenerator(DefaultConfig())
+	result := &ValidationResult{
+		RepoRoot: t.TempDir(),
+		BrokenLinks: []BrokenLink{{
+			SourceFile: "docs/a.md",
+			Line:       4,
+			LinkURL:    "b.md",
+			Reason:     "Relative path not found: b.md",
+			Fix:        &Fix{Title: "Change link to c/b.md", File: "docs/a.md", Line: 4, Old: "](b.md)", New: "](c/b.md)"},
+		}},
+	}
+
+	prompt := generator.GeneratePrompt(result)
+
+	expectedContent := []string{
+		"**Broken links**: 1",
+		"### `docs/a.md`",
+		"- **broken_link** (error, line 4): Relative path not found: b.md",
+		"Replace `](b.md)` with `](c/b.md)`", // The file can't be read
+	}
+	for _, content := range expectedContent {
+		if !strings.Contains(prompt, content) {
+			t.Errorf("Prompt missing expected content: %q\n%s", content, prompt)
+		}
+	}
+}
+
+// manyFindingsResult returns a result with a broken link on every line of
+// several files, backed by files on disk
+func manyFindingsResult(t *testing.T, files, links int) *ValidationResult {
+	t.Helper()
+	tmpDir := t.TempDir()
+	result := &ValidationResult{RepoRoot: tmpDir, Errors: []error{errors.New("test error")}}
+	for f := 0; f < files; f++ {
+		name := fmt.Sprintf("docs/file-%02d.md", f)
+		var content strings.Builder
+		for l := 1; l <= links; l++ {
+			fmt.Fprintf(&content, "See [link %d](missing-%d.md) for details.\n", l, l)
+			result.BrokenLinks = append(result.BrokenLinks, BrokenLink{
+				SourceFile: name,
+				Line:       l,
+				LinkURL:    fmt.Sprintf("missing-%d.md", l),
+				Reason:     fmt.Sprintf("Relative path not found: missing-%d.md", l),
+			})
+		}
+		writeTestFile(t, tmpDir, name, content.String())
+	}
+	return result
+}
+
+func TestGeneratePrompts_Profiles(t *testing.T) {
+	result := manyFindingsResult(t, 1, 2)
+	result.OrphanedFiles = []string{"templates/orphan.txt"}
+
+	for _, profile := range PromptProfiles() {
+		config := DefaultConfig()
+		config.PromptProfile = profile
+		prompts, err := NewPromptGenerator(config).GeneratePrompts(result)
+		if err != nil {
+			t.Fatalf("GeneratePrompts(%s) error = %v", profile, err)
+		}
+		if len(prompts) != 1 {
+			t.Fatalf("GeneratePrompts(%s) = %d prompts, want 1", profile, len(prompts))
+		}
+		for _, content := range []string{"**Broken links**: 2", "test error", "templates/orphan.txt", "### `docs/file-00.md`", ">    2 | See [link 2]"} {
+			if !strings.Contains(prompts[0], content) {
+				t.Errorf("profile %s prompt missing %q", profile, content)
+			}
+		}
+	}
+
+	for _, want := range []string{"default", "amp", "codex", "copilot", "gemini"} {
+		if !containsString(PromptProfiles(), want) {
+			t.Errorf("PromptProfiles() = %v, want %s", PromptProfiles(), want)
+		}
+	}
+}
+
+func TestGeneratePrompts_CustomTemplate(t *testing.T) {
+	result := manyFindingsResult(t, 1, 1)
+	writeTestFile(t, result.RepoRoot, "prompts/mine.tmpl",
+		"Profile {{.Profile}}: {{.Summary.BrokenLinks}} broken\n{{range .Files}}{{range .Findings}}{{template \"finding\" .}}{{end}}{{end}}")
+
+	config := DefaultConfig()
+	config.RepoRoot = result.RepoRoot
+	config.PromptProfile = "prompts/mine.tmpl"
+	prompts, err := NewPromptGenerator(config).GeneratePrompts(result)
+	if err != nil {
+		t.Fatalf("GeneratePrompts() error = %v", err)
+	}
+	want := "Profile prompts/mine.tmpl: 1 broken\n- **broken_link** (error, line 1): Relative path not found: missing-1.md"
+	if len(prompts) != 1 || !strings.HasPrefix(prompts[0], want) {
+		t.Errorf("GeneratePrompts() = %q, want prefix %q", prompts, want)
+	}
+
+	for _, profile := range []string{"nope", "common", filepath.Join(result.RepoRoot, "missing.tmpl")} {
+		config.PromptProfile = profile
+		if _, err := NewPromptGenerator(config).GeneratePrompts(result); err == nil {
+			t.Errorf("GeneratePrompts() with profile %s error = nil, want error", profile)
+		}
+	}
+}
+
+func TestGeneratePrompts_Split(t *testing.T) {
+	result := manyFindingsResult(t, 6, 8)
+
+	// One file whose findings alone exceed the budget
+	writeTestFile(t, result.RepoRoot, "docs/big.md", strings.Repeat("See [link](missing.md) for details.\n", 60))
+	for l := 1; l <= 60; l++ {
+		result.BrokenLinks = append(result.BrokenLinks, BrokenLink{
+			SourceFile: "docs/big.md", Line: l, LinkURL: "missing.md", Reason: "Relative path not found: missing.md",
+		})
+	}
+
+	config := DefaultConfig()
+	config.PromptTokens = 2500
+	prompts, err := NewPromptGenerator(config).GeneratePrompts(result)
+	if err != nil {
+		t.Fatalf("GeneratePrompts() error = %v", err)
+	}
+	if len(prompts) < 3 {
+		t.Fatalf("GeneratePrompts() = %d prompts, want the findings split", len(prompts))
+	}
+
+	for i, prompt := range prompts {
+		if tokens := estimateTokens(prompt); tokens > config.PromptTokens {
+			t.Errorf("prompt %d is %d tokens, over the budget of %d", i+1, tokens, config.PromptTokens)
+		}
+		if want := fmt.Sprintf("(part %d of %d)", i+1, len(prompts)); !strings.Contains(prompt, want) {
+			t.Errorf("prompt %d missing %q", i+1, want)
+		}
+		if got := strings.Contains(prompt, "test error"); got != (i == 0) {
+			t.Errorf("prompt %d lists critical errors = %v, want only in the first", i+1, got)
+		}
+	}
+
+	// Every finding appears exactly once across the parts
+	all := strings.Join(prompts, "\n")
+	if got, want := strings.Count(all, "- **broken_link**"), len(result.BrokenLinks); got != want {
+		t.Errorf("findings across prompts = %d, want %d", got, want)
+	}
+	for _, link := range result.BrokenLinks[:48] {
+		want := fmt.Sprintf("(error, line %d): %s", link.Line, link.Reason)
+		if !strings.Contains(all, want) {
+			t.Errorf("prompts missing %s %s", link.SourceFile, want)
+		}
+	}
+
+	// Without a budget everything is one prompt
+	config.PromptTokens = 0
+	if prompts, err := NewPromptGenerator(config).GeneratePrompts(result); err != nil || len(prompts) != 1 {
+		t.Errorf("GeneratePrompts() with no budget = %d prompts, %v; want 1", len(prompts), err)
+	}
+}
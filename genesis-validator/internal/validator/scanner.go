@@ -1,37 +1,44 @@
 package validator
 
 import (
-	"os"
+	"context"
+	"io/fs"
 	"path/filepath"
-	"strings"
+	"regexp"
 )
 
+// templateNamePattern matches template naming conventions: a "-template" stem
+// (index-template.html) or a ".template" suffix (deploy-web.sh.template)
+var templateNamePattern = regexp.MustCompile(`-template(\.[^.]+)*$|\.template$`)
+
 // Scanner scans the genesis directory for template files
 type Scanner struct {
 	config *Config
+	ws     *workspace
 }
 
 // NewScanner creates a new Scanner
 func NewScanner(config *Config) *Scanner {
-	return &Scanner{config: config}
+	ws, config := openWorkspace(config, nil)
+	return &Scanner{config: config, ws: ws}
 }
 
 // ScanTemplates finds all template files in the genesis/templates directory
-func (s *Scanner) ScanTemplates() ([]string, error) {
+func (s *Scanner) ScanTemplates(ctx context.Context) ([]string, error) {
 	var templates []string
 
-	err := filepath.Walk(s.config.TemplatesDir, func(path string, info os.FileInfo, err error) error {
+	err := s.ws.Walk(ctx, s.config.TemplatesDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
 		// Skip directories
-		if info.IsDir() {
+		if d.IsDir() {
 			return nil
 		}
 
 		// Check if file is a template (ends with -template* or .template)
-		if s.isTemplateFile(info.Name()) {
+		if s.isTemplateFile(d.Name()) {
 			// Store relative path from genesis root
 			relPath, err := filepath.Rel(s.config.GenesisRoot, path)
 			if err != nil {
@@ -52,22 +59,11 @@ func (s *Scanner) ScanTemplates() ([]string, error) {
 
 // isTemplateFile checks if a filename matches template naming conventions
 func (s *Scanner) isTemplateFile(filename string) bool {
-	// Check for -template suffix (e.g., index-template.html)
-	if strings.Contains(filename, "-template") {
-		return true
-	}
-
-	// Check for .template suffix (e.g., deploy-web.sh.template)
-	if strings.HasSuffix(filename, ".template") {
-		return true
-	}
-
-	return false
+	return templateNamePattern.MatchString(filename)
 }
 
 // FileExists checks if a file exists
 func (s *Scanner) FileExists(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil
+	return s.ws.Exists(path)
 }
 
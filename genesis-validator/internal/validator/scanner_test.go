@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -21,6 +22,8 @@ func TestScanner_isTemplateFile(t *testing.T) {
 		{"not a template", "README.md", false},
 		{"not a template", "app.js", false},
 		{"template substring", "implementation.md", false},
+		{"templates plural", "document-specific-templates.js", false},
+		{"template with minified suffix", "app-template.min.js", true},
 	}
 
 	for _, tt := range tests {
@@ -66,7 +69,7 @@ func TestScanner_ScanTemplates(t *testing.T) {
 	scanner := NewScanner(config)
 
 	// Scan templates
-	templates, err := scanner.ScanTemplates()
+	templates, err := scanner.ScanTemplates(context.Background())
 	if err != nil {
 		t.Fatalf("ScanTemplates() error = %v", err)
 	}
@@ -115,4 +118,3 @@ func TestScanner_FileExists(t *testing.T) {
 		t.Errorf("FileExists() = false for existing file")
 	}
 }
-
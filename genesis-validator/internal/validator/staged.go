@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// ValidateStagedLinks validates links in markdown files changed in the git index.
+// Content is read from the staged blobs rather than the working tree, and link
+// targets are resolved against the index. Inbound links to files renamed or
+// deleted in the index are re-checked using a reverse link index.
+func (lv *LinkValidator) ValidateStagedLinks(repo *GitRepo) ([]BrokenLink, error) {
+	changes, err := repo.StagedChanges()
+	if err != nil {
+		return nil, err
+	}
+
+	indexFiles, err := repo.StagedFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	staged := &LinkValidator{config: lv.config, ws: lv.ws, exists: newPathSet(indexFiles).has, read: repo.ReadStaged, refs: lv.refs}
+
+	var brokenLinks []BrokenLink
+	checked := make(map[string]bool)
+	var removed []string
+
+	for _, change := range changes {
+		switch change.Status {
+		case 'D':
+			removed = append(removed, change.Path)
+			continue
+		case 'R':
+			removed = append(removed, change.OldPath)
+		}
+
+		if !strings.HasSuffix(change.Path, ".md") || isExcludedPath(change.Path) {
+			continue
+		}
+
+		content, err := repo.ReadStaged(change.Path)
+		if err != nil {
+			return nil, err
+		}
+		links, err := extractLinksFrom(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+
+		checked[change.Path] = true
+		for _, link := range links {
+			if broken := staged.validateLink(change.Path, link); broken != nil {
+				brokenLinks = append(brokenLinks, *broken)
+			}
+		}
+	}
+
+	if len(removed) == 0 {
+		return brokenLinks, nil
+	}
+
+	// Re-check links in unchanged files that pointed at removed paths
+	var mdFiles []string
+	for _, file := range indexFiles {
+		if strings.HasSuffix(file, ".md") && !isExcludedPath(file) {
+			mdFiles = append(mdFiles, file)
+		}
+	}
+	index := staged.BuildLinkIndex(mdFiles, repo.ReadStaged)
+
+	sort.Strings(removed)
+	for _, path := range removed {
+		for _, inbound := range index.Inbound(path) {
+			if checked[inbound.SourceFile] {
+				continue
+			}
+			if broken := staged.validateLink(inbound.SourceFile, inbound.link); broken != nil {
+				brokenLinks = append(brokenLinks, *broken)
+			}
+		}
+	}
+
+	return dedupeBrokenLinks(brokenLinks), nil
+}
+
+// dedupeBrokenLinks removes repeated reports of the same link occurrence
+func dedupeBrokenLinks(links []BrokenLink) []BrokenLink {
+	type key struct {
+		file string
+		line int
+		url  string
+	}
+	seen := make(map[key]bool)
+	var unique []BrokenLink
+	for _, link := range links {
+		k := key{link.SourceFile, link.Line, link.LinkURL}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, link)
+	}
+	return unique
+}
@@ -0,0 +1,157 @@
+package validator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// setupGitRepo creates a git repository with the given committed files
+func setupGitRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test")
+
+	for name, content := range files {
+		writeTestFile(t, dir, name, content)
+	}
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	fullPath := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+func TestParseNameStatus(t *testing.T) {
+	out := []byte("M\x00a.md\x00R087\x00old.md\x00new.md\x00D\x00gone.md\x00")
+
+	changes, err := parseNameStatus(out)
+	if err != nil {
+		t.Fatalf("parseNameStatus() error = %v", err)
+	}
+
+	want := []StagedChange{
+		{Status: 'M', Path: "a.md"},
+		{Status: 'R', Path: "new.md", OldPath: "old.md"},
+		{Status: 'D', Path: "gone.md"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("parseNameStatus() got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+}
+
+func TestValidateStagedLinks_ReadsStagedContent(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{
+		"docs/a.md": "# A\n",
+		"docs/b.md": "# B\n",
+	})
+
+	// Stage a broken link, then fix it only in the working tree
+	writeTestFile(t, dir, "docs/a.md", "# A\n\nSee [B](b.md) and [C](c.md).\n")
+	runGit(t, dir, "add", "docs/a.md")
+	writeTestFile(t, dir, "docs/a.md", "# A\n\nSee [B](b.md).\n")
+
+	lv := NewLinkValidator(DefaultConfig())
+	broken, err := lv.ValidateStagedLinks(NewGitRepo(dir))
+	if err != nil {
+		t.Fatalf("ValidateStagedLinks() error = %v", err)
+	}
+
+	if len(broken) != 1 {
+		t.Fatalf("ValidateStagedLinks() found %d broken links, want 1: %+v", len(broken), broken)
+	}
+	if broken[0].SourceFile != "docs/a.md" || broken[0].LinkURL != "c.md" || broken[0].Line != 3 {
+		t.Errorf("unexpected broken link: %+v", broken[0])
+	}
+}
+
+func TestValidateStagedLinks_InboundToRemovedFiles(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{
+		"README.md":         "# Readme\n\n[Guide](docs/guide.md)\n[Old](docs/old.md)\n",
+		"docs/guide.md":     "# Guide\n",
+		"docs/old.md":       "# Old\n",
+		"docs/index.md":     "[Guide](guide.md)\n",
+		"docs/unrelated.md": "[Broken](missing.md)\n",
+	})
+
+	runGit(t, dir, "mv", "docs/guide.md", "docs/handbook.md")
+	runGit(t, dir, "rm", "-q", "docs/old.md")
+
+	lv := NewLinkValidator(DefaultConfig())
+	broken, err := lv.ValidateStagedLinks(NewGitRepo(dir))
+	if err != nil {
+		t.Fatalf("ValidateStagedLinks() error = %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, link := range broken {
+		got[link.SourceFile+"->"+link.LinkURL] = true
+	}
+
+	want := []string{
+		"README.md->docs/guide.md",
+		"README.md->docs/old.md",
+		"docs/index.md->guide.md",
+	}
+	if len(broken) != len(want) {
+		t.Errorf("ValidateStagedLinks() found %d broken links, want %d: %+v", len(broken), len(want), broken)
+	}
+	for _, w := range want {
+		if !got[w] {
+			t.Errorf("expected broken link %s", w)
+		}
+	}
+
+	// Pre-existing breakage in files untouched by the commit is not reported
+	if got["docs/unrelated.md->missing.md"] {
+		t.Error("unrelated file should not be validated in staged mode")
+	}
+}
+
+func TestLinkIndex_Inbound(t *testing.T) {
+	files := map[string]string{
+		"a.md":      "[x](docs/target.md)\n",
+		"docs/b.md": "[y](target.md#section)\n[z](other.md)\n",
+	}
+	read := func(path string) ([]byte, error) { return []byte(files[path]), nil }
+
+	idx := NewLinkValidator(DefaultConfig()).BuildLinkIndex([]string{"a.md", "docs/b.md"}, read)
+	inbound := idx.Inbound("docs/target.md")
+
+	if len(inbound) != 2 {
+		t.Fatalf("Inbound() returned %d links, want 2", len(inbound))
+	}
+	if inbound[0].SourceFile != "a.md" || inbound[1].SourceFile != "docs/b.md" {
+		t.Errorf("Inbound() sources = %s, %s", inbound[0].SourceFile, inbound[1].SourceFile)
+	}
+}
@@ -0,0 +1,192 @@
+package validator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// stepFilePattern matches numbered step files such as 03-copy-templates.md
+var stepFilePattern = regexp.MustCompile(`^(\d+)-.+\.md$`)
+
+// Step is a numbered step file in the genesis steps directory
+type Step struct {
+	Number int
+	Path   string
+}
+
+// DiscoverSteps returns the NN-*.md files in the steps directory ordered by number
+func DiscoverSteps(dir string) ([]Step, error) {
+	return discoverSteps(os.ReadDir, dir)
+}
+
+// discoverSteps lists the step files of dir using readDir
+func discoverSteps(readDir func(string) ([]fs.DirEntry, error), dir string) ([]Step, error) {
+	entries, err := readDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []Step
+	for _, entry := range entries {
+		match := stepFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(match[1])
+		steps = append(steps, Step{Number: number, Path: filepath.Join(dir, entry.Name())})
+	}
+
+	sort.Slice(steps, func(i, j int) bool {
+		if steps[i].Number != steps[j].Number {
+			return steps[i].Number < steps[j].Number
+		}
+		return steps[i].Path < steps[j].Path
+	})
+	return steps, nil
+}
+
+// validateSteps checks that step numbering is contiguous, that each step has
+// Entry Conditions and Exit Criteria sections and links to its neighbours,
+// and that the START-HERE step table lists every step in order
+func (v *Validator) validateSteps(result *ValidationResult) {
+	steps, err := discoverSteps(v.ws.ReadDir, v.config.StepsDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			result.Errors = append(result.Errors, fmt.Errorf("failed to read steps: %w", err))
+		}
+		return
+	}
+	if len(steps) == 0 {
+		return
+	}
+
+	v.log.Info("found step files", "count", len(steps))
+	result.markChecked("step_missing_link", "step_table", "step_numbering", "step_missing_section")
+
+	report := func(typ, file string, line int, description string) {
+		location := file
+		if line > 0 {
+			location = fmt.Sprintf("%s:%d", file, line)
+		}
+		result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
+			Type:        typ,
+			File:        file,
+			Line:        line,
+			Description: description,
+			Location:    location,
+		})
+	}
+
+	for i, step := range steps {
+		file := displayPath(step.Path)
+
+		if i > 0 {
+			switch prev := steps[i-1].Number; {
+			case step.Number == prev:
+				report("step_numbering", file, 0, fmt.Sprintf("Step number %02d is used by %s too", step.Number, displayPath(steps[i-1].Path)))
+			case step.Number != prev+1:
+				report("step_numbering", file, 0, fmt.Sprintf("Step numbering jumps from %02d to %02d", prev, step.Number))
+			}
+		}
+
+		content, err := v.ws.ReadFile(step.Path)
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+			continue
+		}
+		headings, _ := extractHeadings(strings.NewReader(string(content)))
+		links, _ := extractLinksFrom(strings.NewReader(string(content)))
+
+		entryLine := sectionLine(headings, "entry conditions")
+		exitLine := sectionLine(headings, "exit criteria")
+		if entryLine == 0 {
+			report("step_missing_section", file, 0, "Step has no Entry Conditions section")
+		}
+		if exitLine == 0 {
+			report("step_missing_section", file, 0, "Step has no Exit Criteria section")
+		}
+
+		if i > 0 && !linksTo(step.Path, links, steps[i-1].Path) {
+			report("step_missing_link", file, entryLine, fmt.Sprintf("Step does not link back to previous step %s", filepath.Base(steps[i-1].Path)))
+		}
+		if i < len(steps)-1 && !linksTo(step.Path, links, steps[i+1].Path) {
+			report("step_missing_link", file, 0, fmt.Sprintf("Step does not link forward to next step %s", filepath.Base(steps[i+1].Path)))
+		}
+	}
+
+	v.validateStepTable(steps, report)
+}
+
+// validateStepTable checks that the START-HERE table rows linking to steps
+// list every step exactly once and in order
+func (v *Validator) validateStepTable(steps []Step, report func(typ, file string, line int, description string)) {
+	content, err := v.ws.ReadFile(v.config.StartHereFile)
+	if err != nil {
+		return
+	}
+	startHere := displayPath(v.config.StartHereFile)
+
+	links, _ := extractLinksFrom(strings.NewReader(string(content)))
+	lines := strings.Split(string(content), "\n")
+
+	listed := make(map[string]int) // Step path -> START-HERE line
+	last := -1                     // Highest step index listed so far
+	for _, link := range links {
+		if link.line > len(lines) || !strings.HasPrefix(strings.TrimSpace(lines[link.line-1]), "|") {
+			continue
+		}
+		for i, step := range steps {
+			if !linksTo(v.config.StartHereFile, []linkInfo{link}, step.Path) || listed[step.Path] > 0 {
+				continue
+			}
+			listed[step.Path] = link.line
+			if i < last {
+				report("step_table", startHere, link.line, fmt.Sprintf("Step table lists %s out of order", filepath.Base(step.Path)))
+			} else {
+				last = i
+			}
+		}
+	}
+
+	for _, step := range steps {
+		if listed[step.Path] == 0 {
+			report("step_table", startHere, 0, fmt.Sprintf("Step table does not list %s", filepath.Base(step.Path)))
+		}
+	}
+}
+
+// sectionLine returns the line of the first heading containing name
+// (case-insensitive), or 0 if there is none
+func sectionLine(headings []Heading, name string) int {
+	for _, h := range headings {
+		if strings.Contains(strings.ToLower(h.Text), name) {
+			return h.Line
+		}
+	}
+	return 0
+}
+
+// linksTo reports whether any link in source resolves to target
+func linksTo(source string, links []linkInfo, target string) bool {
+	want, err := filepath.Abs(target)
+	if err != nil {
+		return false
+	}
+	for _, link := range links {
+		url := stripAnchor(link.url)
+		if url == "" || strings.Contains(url, "://") {
+			continue
+		}
+		got, err := filepath.Abs(filepath.Join(filepath.Dir(source), filepath.FromSlash(url)))
+		if err == nil && got == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// writeStep writes a well-formed step file linking to its neighbours
+func writeStep(t *testing.T, dir, name, prev, next string) {
+	t.Helper()
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n## Entry Conditions\n\n", name)
+	if prev != "" {
+		fmt.Fprintf(&b, "- [ ] Completed [previous](%s)\n", prev)
+	}
+	b.WriteString("\n## ⛔ Exit Criteria (ALL MUST PASS)\n\n- [ ] Done\n")
+	if next != "" {
+		fmt.Fprintf(&b, "\n**Next Step:** [next →](%s)\n", next)
+	}
+	writeTestFile(t, dir, "genesis/steps/"+name, b.String())
+}
+
+func TestValidate_StepChain(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	config.StepsDir = tmpDir + "/genesis/steps"
+
+	writeStep(t, tmpDir, "00-start.md", "", "01-middle.md")
+	writeStep(t, tmpDir, "01-middle.md", "00-start.md", "02-end.md")
+	writeStep(t, tmpDir, "02-end.md", "01-middle.md", "")
+	writeTestFile(t, tmpDir, "genesis/START-HERE.md", strings.Join([]string{
+		"# START-HERE",
+		"",
+		"| Step | File |",
+		"|------|------|",
+		"| 0 | [`steps/00-start.md`](steps/00-start.md) |",
+		"| 1 | [`steps/01-middle.md`](steps/01-middle.md) |",
+		"| 2 | [`steps/02-end.md`](steps/02-end.md) |",
+		"",
+		"cp genesis/templates/web-app/index-template.html index.html",
+		"cp genesis/templates/web-app/js/app-template.js js/app.js",
+		"cp genesis/templates/CLAUDE.md.template CLAUDE.md",
+	}, "\n"))
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	for _, inc := range result.Inconsistencies {
+		if strings.HasPrefix(inc.Type, "step_") {
+			t.Errorf("unexpected finding for a complete chain: %+v", inc)
+		}
+	}
+}
+
+func TestValidate_StepChainBroken(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	config.StepsDir = tmpDir + "/genesis/steps"
+
+	writeStep(t, tmpDir, "00-start.md", "", "01-middle.md")
+	writeStep(t, tmpDir, "01-middle.md", "", "03-end.md") // No back link
+	writeTestFile(t, tmpDir, "genesis/steps/03-end.md", "# End\n\n[Back](01-middle.md)\n")
+	writeTestFile(t, tmpDir, "genesis/START-HERE.md", strings.Join([]string{
+		"# START-HERE",
+		"",
+		"| Step | File |",
+		"|------|------|",
+		"| 1 | [`steps/01-middle.md`](steps/01-middle.md) |",
+		"| 0 | [`steps/00-start.md`](steps/00-start.md) |",
+		"",
+		"See [the end](steps/03-end.md).",
+	}, "\n"))
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var got []string
+	for _, inc := range result.Inconsistencies {
+		if strings.HasPrefix(inc.Type, "step_") {
+			got = append(got, fmt.Sprintf("%s %s:%d %s", inc.Type, inc.File, inc.Line, inc.Description))
+		}
+	}
+
+	want := []string{
+		"step_table genesis/START-HERE.md:0 Step table does not list 03-end.md",
+		"step_table genesis/START-HERE.md:6 Step table lists 00-start.md out of order",
+		"step_missing_link genesis/steps/01-middle.md:3 Step does not link back to previous step 00-start.md",
+		"step_missing_section genesis/steps/03-end.md:0 Step has no Entry Conditions section",
+		"step_missing_section genesis/steps/03-end.md:0 Step has no Exit Criteria section",
+		"step_numbering genesis/steps/03-end.md:0 Step numbering jumps from 01 to 03",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("step findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,172 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// suppressionPattern matches genesis-validator HTML comment directives:
+//
+//	<!-- genesis-validator-disable-next-line broken_link -->
+//	<!-- genesis-validator-disable broken_link -->
+//	<!-- genesis-validator-enable broken_link -->
+var suppressionPattern = regexp.MustCompile(`<!--\s*genesis-validator-(disable-next-line|disable|enable)\b([^>]*?)-->`)
+
+// knownRules lists the rule IDs that suppressions may name: rules whose
+// findings point at a markdown file, or a line in one. Findings about a
+// whole file are covered by a disable block that is never re-enabled.
+var knownRules = map[string]bool{
+	"broken_link":          true,
+	"case_mismatch":        true,
+	"external_link_error":  true,
+	"tier_mismatch":        true,
+	"destination_mismatch": true,
+	"step_missing_link":    true,
+	"step_table":           true,
+	"step_numbering":       true,
+	"step_missing_section": true,
+	"missing_anchor":       true,
+	"over_budget":          true,
+	"missing_breadcrumb":   true,
+	"unlinked_child":       true,
+	"unreachable_doc":      true,
+}
+
+// suppression is a parsed disable directive covering lines [from, to] of a file
+type suppression struct {
+	rule string
+	file string
+	line int // Line of the directive itself
+	from int
+	to   int // 0 means end of file
+	used bool
+}
+
+// covers reports whether the suppression applies to a finding. A finding
+// without a line is covered only by a block running to the end of the file.
+func (s *suppression) covers(inc Inconsistency) bool {
+	if inc.Type != s.rule || inc.File != s.file {
+		return false
+	}
+	if inc.Line == 0 {
+		return s.to == 0
+	}
+	return inc.Line >= s.from && (s.to == 0 || inc.Line <= s.to)
+}
+
+// parseSuppressions extracts suppression directives from a markdown file.
+// Malformed directives are returned as invalid_suppression findings.
+func parseSuppressions(file string, content []byte) ([]*suppression, []Inconsistency) {
+	var suppressions []*suppression
+	var problems []Inconsistency
+	open := make(map[string]*suppression) // Active disable blocks by rule
+
+	invalid := func(line int, description string) {
+		problems = append(problems, Inconsistency{
+			Type:        "invalid_suppression",
+			File:        file,
+			Line:        line,
+			Description: description,
+			Location:    fmt.Sprintf("%s:%d", file, line),
+		})
+	}
+
+	_ = scanMarkdownLines(bytes.NewReader(content), func(lineNum int, line string) {
+		for _, match := range suppressionPattern.FindAllStringSubmatch(maskInlineCode(line), -1) {
+			directive := match[1]
+			rules := strings.FieldsFunc(match[2], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+
+			if len(rules) == 0 {
+				invalid(lineNum, fmt.Sprintf("genesis-validator-%s must name at least one rule", directive))
+				continue
+			}
+
+			for _, rule := range rules {
+				if !knownRules[rule] {
+					invalid(lineNum, fmt.Sprintf("Unknown rule %q in genesis-validator-%s", rule, directive))
+					continue
+				}
+
+				switch directive {
+				case "disable-next-line":
+					suppressions = append(suppressions, &suppression{
+						rule: rule, file: file, line: lineNum, from: lineNum + 1, to: lineNum + 1,
+					})
+				case "disable":
+					if open[rule] != nil {
+						invalid(lineNum, fmt.Sprintf("Rule %q is already disabled at line %d", rule, open[rule].line))
+						continue
+					}
+					s := &suppression{rule: rule, file: file, line: lineNum, from: lineNum + 1}
+					open[rule] = s
+					suppressions = append(suppressions, s)
+				case "enable":
+					s := open[rule]
+					if s == nil {
+						invalid(lineNum, fmt.Sprintf("genesis-validator-enable for %q has no matching disable", rule))
+						continue
+					}
+					s.to = lineNum - 1
+					delete(open, rule)
+				}
+			}
+		}
+	})
+
+	return suppressions, problems
+}
+
+// applySuppressions removes findings covered by inline suppression comments in
+// the given markdown files, then reports malformed suppressions and unused ones.
+// Unused suppressions are only reported where their rule was fully evaluated,
+// as decided by judged, since a rule that didn't run can't have matched.
+func applySuppressions(result *ValidationResult, files []string, judged func(file, rule string) bool, read func(string) ([]byte, error)) {
+	var suppressions []*suppression
+	var problems []Inconsistency
+
+	for _, file := range files {
+		content, err := read(file)
+		if err != nil || !bytes.Contains(content, []byte("genesis-validator-")) {
+			continue
+		}
+		s, p := parseSuppressions(file, content)
+		suppressions = append(suppressions, s...)
+		problems = append(problems, p...)
+	}
+
+	if len(suppressions) > 0 {
+		result.removeFindings(func(inc Inconsistency) bool {
+			for _, s := range suppressions {
+				if s.covers(inc) {
+					s.used = true
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	for _, s := range suppressions {
+		if s.used || !judged(s.file, s.rule) {
+			continue
+		}
+		problems = append(problems, Inconsistency{
+			Type:        "unused_suppression",
+			File:        s.file,
+			Line:        s.line,
+			Description: fmt.Sprintf("Suppression for %q does not match any finding", s.rule),
+			Location:    fmt.Sprintf("%s:%d", s.file, s.line),
+		})
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+	result.Inconsistencies = append(result.Inconsistencies, problems...)
+}
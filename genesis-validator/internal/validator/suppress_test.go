@@ -0,0 +1,175 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	content := strings.Join([]string{
+		"<!-- genesis-validator-disable-next-line broken_link -->", // 1
+		"[x](missing.md)", // 2
+		"<!-- genesis-validator-disable broken_link -->", // 3
+		"[y](missing.md)", // 4
+		"<!-- genesis-validator-enable broken_link -->",   // 5
+		"<!-- genesis-validator-disable-next-line -->",    // 6
+		"<!-- genesis-validator-disable no_such_rule -->", // 7
+		"<!-- genesis-validator-enable broken_link -->",   // 8
+		"`<!-- genesis-validator-disable -->` in prose",   // 9
+	}, "\n")
+
+	suppressions, problems := parseSuppressions("doc.md", []byte(content))
+
+	if len(suppressions) != 2 {
+		t.Fatalf("parseSuppressions() found %d suppressions, want 2", len(suppressions))
+	}
+	if s := suppressions[0]; s.from != 2 || s.to != 2 {
+		t.Errorf("next-line suppression covers %d-%d, want 2-2", s.from, s.to)
+	}
+	if s := suppressions[1]; s.from != 4 || s.to != 4 {
+		t.Errorf("block suppression covers %d-%d, want 4-4", s.from, s.to)
+	}
+
+	// Missing rule, unknown rule and unmatched enable; inline code is ignored
+	if len(problems) != 3 {
+		t.Fatalf("parseSuppressions() found %d problems, want 3: %+v", len(problems), problems)
+	}
+	for i, line := range []int{6, 7, 8} {
+		if problems[i].Line != line || problems[i].Type != "invalid_suppression" {
+			t.Errorf("problem %d = %+v, want invalid_suppression at line %d", i, problems[i], line)
+		}
+	}
+}
+
+func TestValidate_InlineSuppressions(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	writeTestFile(t, tmpDir, "docs/guide.md", strings.Join([]string{
+		"# Guide",
+		"<!-- genesis-validator-disable-next-line broken_link -->",
+		"[Derived](genesis-tools/genesis/project-diff)",
+		"<!-- genesis-validator-disable broken_link -->",
+		"[Example](path/in/your/project.md)",
+		"<!-- genesis-validator-enable broken_link -->",
+		"[Real](real-typo.md)",
+		"<!-- genesis-validator-disable-next-line broken_link -->",
+		"Nothing to suppress here.",
+	}, "\n"))
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if len(result.BrokenLinks) != 1 || result.BrokenLinks[0].LinkURL != "real-typo.md" {
+		t.Errorf("expected only the unsuppressed broken link, got %+v", result.BrokenLinks)
+	}
+
+	var unused []Inconsistency
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "unused_suppression" {
+			unused = append(unused, inc)
+		}
+	}
+	if len(unused) != 1 || unused[0].Line != 8 {
+		t.Errorf("expected one unused suppression at line 8, got %+v", unused)
+	}
+}
+
+func TestValidate_SuppressionsForRulesNotRun(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	config.EntryPoints = []string{"README.md"}
+	writeTestFile(t, tmpDir, "README.md", "# Readme\n\n[Guide](docs/guide.md)\n[Start](genesis/START-HERE.md)\n[Checklist](genesis/00-AI-MUST-READ-FIRST.md)\n")
+	writeTestFile(t, tmpDir, "docs/guide.md", strings.Join([]string{
+		"# Guide",
+		"<!-- genesis-validator-disable-next-line case_mismatch -->",
+		"[Readme](../README.md)",
+		"<!-- genesis-validator-disable-next-line external_link_error -->",
+		"[Site](https://example.com)",
+	}, "\n"))
+	writeTestFile(t, tmpDir, "docs/draft.md", "<!-- genesis-validator-disable unreachable_doc -->\n# Draft\n")
+	writeTestFile(t, tmpDir, "docs/stray.md", "# Stray\n")
+	chdir(t, tmpDir)
+
+	// Not a git repository and external links are off, so neither rule ran
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var got []string
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "unused_suppression" || inc.Type == "unreachable_doc" || inc.Type == "invalid_suppression" {
+			got = append(got, inc.Type+" "+inc.File)
+		}
+	}
+	if want := []string{"unreachable_doc docs/stray.md"}; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestValidateStaged_SuppressionsForRulesNotRun(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{"docs/guide.md": "# Guide\n"})
+	writeTestFile(t, dir, "README.md", strings.Join([]string{
+		"# Readme",
+		"<!-- genesis-validator-disable-next-line case_mismatch -->",
+		"[Guide](docs/guide.md)",
+		"<!-- genesis-validator-disable-next-line broken_link -->",
+		"Nothing broken here.",
+	}, "\n"))
+	runGit(t, dir, "add", "README.md")
+	chdir(t, dir)
+
+	config := DefaultConfig()
+	config.RepoRoot = dir
+	result, err := NewValidator(config).ValidateStaged(NewGitRepo(dir))
+	if err != nil {
+		t.Fatalf("ValidateStaged() error = %v", err)
+	}
+
+	var unused []string
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "unused_suppression" {
+			unused = append(unused, fmt.Sprintf("%s:%d", inc.File, inc.Line))
+		}
+	}
+	if want := []string{"README.md:4"}; strings.Join(unused, ",") != strings.Join(want, ",") {
+		t.Errorf("unused suppressions = %v, want %v (staged mode only runs broken_link)", unused, want)
+	}
+}
+
+func TestValidate_SuppressFileLevelStepRules(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	config.StepsDir = tmpDir + "/genesis/steps"
+	config.EntryPoints = nil
+
+	// No Exit Criteria, suppressed for the whole file
+	writeTestFile(t, tmpDir, "genesis/steps/01-end.md", strings.Join([]string{
+		"<!-- genesis-validator-disable step_missing_section -->",
+		"# 01-end.md",
+		"",
+		"## Entry Conditions",
+		"",
+		"- [ ] Completed [previous](00-start.md)",
+	}, "\n"))
+	// Numbering is fine, so this one is unused
+	writeTestFile(t, tmpDir, "genesis/steps/00-start.md", "<!-- genesis-validator-disable step_numbering -->\n"+
+		"# 00-start.md\n\n## Entry Conditions\n\n## ⛔ Exit Criteria (ALL MUST PASS)\n\n**Next Step:** [next →](01-end.md)\n")
+	chdir(t, tmpDir)
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	var got []string
+	for _, inc := range result.Inconsistencies {
+		if strings.HasPrefix(inc.File, "genesis/steps/") {
+			got = append(got, fmt.Sprintf("%s %s:%d", inc.Type, inc.File, inc.Line))
+		}
+	}
+	if want := []string{"unused_suppression genesis/steps/00-start.md:1"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
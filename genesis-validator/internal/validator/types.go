@@ -1,26 +1,109 @@
 package validator
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
 
 // ValidationResult represents the result of a Genesis validation
 type ValidationResult struct {
 	TemplateFiles   []string
-	ReferencedFiles map[string][]string // file -> list of docs that reference it
+	ReferencedFiles map[string][]string    // file -> list of docs that reference it
+	References      map[string][]Reference // file -> every doc line that references it
 	OrphanedFiles   []string
 	MissingFiles    []string
 	BrokenLinks     []BrokenLink // Broken markdown links
 	Inconsistencies []Inconsistency
 	Errors          []error
+	Baselined       int    // Findings suppressed because they are recorded in a baseline
+	RepoRoot        string // Repository root that finding paths are relative to; "" for a virtual tree
+
+	readFile func(string) ([]byte, error) // Reads the validated files, for baseline fingerprints
+	checked  map[string]bool              // Rules evaluated on every file, for judging unused suppressions
+}
+
+// source reads a file named by a finding the way validation read it, or from
+// the repository root on disk
+func (r *ValidationResult) source(file string) ([]byte, error) {
+	if r.readFile != nil {
+		return r.readFile(file)
+	}
+	return os.ReadFile(filepath.Join(r.RepoRoot, filepath.FromSlash(file)))
+}
+
+// Severity ranks a finding: errors fail validation, warnings don't
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is one problem found by validation, in a form suited to tools
+type Finding struct {
+	Rule     string   `json:"rule"` // e.g. broken_link, orphaned_file, over_budget
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"` // Repo-relative, slash-separated
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+	Location string   `json:"location,omitempty"`
+}
+
+// errorRules are the finding types reported as errors. Case mismatches break
+// on case-sensitive filesystems, so they are errors too.
+var errorRules = map[string]bool{"orphaned_file": true, "missing_file": true, "broken_link": true, "case_mismatch": true}
+
+// Findings returns every finding in the result as structured data, in the
+// result's order
+func (r *ValidationResult) Findings() []Finding {
+	findings := make([]Finding, 0, len(r.Inconsistencies))
+	for _, inc := range r.Inconsistencies {
+		severity := SeverityWarning
+		if errorRules[inc.Type] {
+			severity = SeverityError
+		}
+		findings = append(findings, Finding{
+			Rule:     inc.Type,
+			Severity: severity,
+			File:     inc.File,
+			Line:     inc.Line,
+			Message:  inc.Description,
+			Location: inc.Location,
+		})
+	}
+	return findings
 }
 
 // Inconsistency represents a discrepancy between documentation files
 type Inconsistency struct {
 	Type        string // "missing_reference", "orphaned_file", "doc_mismatch"
 	File        string
+	Line        int // Line number within File, 0 if not line-specific
 	Description string
 	Location    string // e.g., "START-HERE.md:line 123"
 }
 
+// newValidationResult creates an empty ValidationResult
+func newValidationResult() *ValidationResult {
+	return &ValidationResult{
+		ReferencedFiles: make(map[string][]string),
+		References:      make(map[string][]Reference),
+	}
+}
+
+// markChecked records rules that were evaluated across the whole repository
+func (r *ValidationResult) markChecked(rules ...string) {
+	if r.checked == nil {
+		r.checked = make(map[string]bool)
+	}
+	for _, rule := range rules {
+		r.checked[rule] = true
+	}
+}
+
 // IsValid returns true if validation passed with no critical issues
 func (r *ValidationResult) IsValid() bool {
 	return len(r.OrphanedFiles) == 0 &&
@@ -48,18 +131,141 @@ func (r *ValidationResult) Summary() string {
 	summary += fmt.Sprintf("  Broken links: %d\n", len(r.BrokenLinks))
 	summary += fmt.Sprintf("  Inconsistencies: %d\n", len(r.Inconsistencies))
 	summary += fmt.Sprintf("  Errors: %d\n", len(r.Errors))
+	if r.Baselined > 0 {
+		summary += fmt.Sprintf("  Baselined (ignored): %d\n", r.Baselined)
+	}
 
 	return summary
 }
 
+// Sort orders every finding list so output does not depend on map iteration
+// or filesystem walk order
+func (r *ValidationResult) Sort() {
+	sort.Strings(r.OrphanedFiles)
+	sort.Strings(r.MissingFiles)
+	sort.SliceStable(r.BrokenLinks, func(i, j int) bool {
+		a, b := r.BrokenLinks[i], r.BrokenLinks[j]
+		if a.SourceFile != b.SourceFile {
+			return a.SourceFile < b.SourceFile
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.LinkURL < b.LinkURL
+	})
+	sortInconsistencies(r.Inconsistencies)
+}
+
+// removeFindings removes every inconsistency for which drop returns true,
+// along with the orphaned file, missing file or broken link entry backing it,
+// and returns how many were removed
+func (r *ValidationResult) removeFindings(drop func(Inconsistency) bool) int {
+	var kept []Inconsistency
+	dropped := make(map[findingKey]int)
+
+	for _, inc := range r.Inconsistencies {
+		if drop(inc) {
+			dropped[keyOf(inc.Type, inc.File, inc.Line, inc.Description)]++
+			continue
+		}
+		kept = append(kept, inc)
+	}
+	removed := len(r.Inconsistencies) - len(kept)
+	r.Inconsistencies = kept
+
+	take := func(key findingKey) bool {
+		if dropped[key] == 0 {
+			return false
+		}
+		dropped[key]--
+		return true
+	}
+
+	var orphaned, missing []string
+	for _, file := range r.OrphanedFiles {
+		if !take(keyOf("orphaned_file", file, 0, "")) {
+			orphaned = append(orphaned, file)
+		}
+	}
+	for _, file := range r.MissingFiles {
+		if !take(keyOf("missing_file", file, 0, "")) {
+			missing = append(missing, file)
+		}
+	}
+	r.OrphanedFiles, r.MissingFiles = orphaned, missing
+
+	var links []BrokenLink
+	for _, link := range r.BrokenLinks {
+		if !take(keyOf(link.findingType(), link.SourceFile, link.Line, link.Reason)) {
+			links = append(links, link)
+		}
+	}
+	r.BrokenLinks = links
+
+	return removed
+}
+
+// findingKey identifies the typed result entry behind an inconsistency
+type findingKey struct {
+	rule, file, description string
+	line                    int
+}
+
+// keyOf builds a findingKey. Orphaned and missing files are identified by
+// file alone, since the typed lists only hold file paths.
+func keyOf(rule, file string, line int, description string) findingKey {
+	if rule == "orphaned_file" || rule == "missing_file" {
+		return findingKey{rule: rule, file: file}
+	}
+	return findingKey{rule: rule, file: file, line: line, description: description}
+}
+
+// sortInconsistencies orders inconsistencies by file, line, type and description
+func sortInconsistencies(incs []Inconsistency) {
+	sort.SliceStable(incs, func(i, j int) bool {
+		a, b := incs[i], incs[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Description != b.Description {
+			return a.Description < b.Description
+		}
+		return a.Location < b.Location
+	})
+}
+
 // Config holds configuration for the validator
 type Config struct {
-	GenesisRoot    string
-	TemplatesDir   string
-	StartHereFile  string
-	ChecklistFile  string
-	Verbose        bool
-	GeneratePrompt bool
+	RepoRoot          string             `json:"repoRoot"` // Repository root; found by walking up to .git when empty
+	GenesisRoot       string             `json:"genesisRoot"`
+	TemplatesDir      string             `json:"templatesDir"`
+	StartHereFile     string             `json:"startHereFile"`
+	ChecklistFile     string             `json:"checklistFile"`
+	StepsDir          string             `json:"stepsDir"`          // Directory of numbered NN-*.md step files
+	ManifestFile      string             `json:"manifestFile"`      // Template manifest with tier and destination per template
+	BaselineDir       string             `json:"baselineDir"`       // Reference project the steps copy from
+	NotCopied         []string           `json:"notCopied"`         // Baseline globs deliberately not copied
+	ReferenceDocs     []string           `json:"referenceDocs"`     // Docs or globs parsed for template references
+	Budgets           []Budget           `json:"budgets"`           // Size limits for markdown files; first matching glob wins
+	SplitDirs         []string           `json:"splitDirs"`         // Folders whose files must all carry a "Part of" breadcrumb
+	EntryPoints       []string           `json:"entryPoints"`       // Docs every other doc must be reachable from by links
+	UnreachableIgnore []string           `json:"unreachableIgnore"` // Doc globs exempt from the reachability check
+	GitHubRepo        string             `json:"githubRepo"`        // owner/name of this repository on GitHub
+	DefaultBranch     string             `json:"defaultBranch"`     // GitHub URLs on this branch are checked against the working tree
+	ExternalLinks     ExternalLinkConfig `json:"externalLinks"`     // Opt-in check of http(s) links outside this repository
+	IncludeUntracked  bool               `json:"includeUntracked"`  // Also validate untracked files git does not ignore
+	Strict            bool               `json:"strict"`            // Links must resolve from the source file and stay inside the repository
+	Verbose           bool               `json:"verbose"`
+	GeneratePrompt    bool               `json:"generatePrompt"`
+	PromptProfile     string             `json:"promptProfile"` // Built-in prompt profile name or .tmpl file
+	PromptTokens      int                `json:"promptTokens"`  // Token budget per prompt; larger results are split, 0 for no limit
+	Agent             AgentConfig        `json:"agent"`         // Opt-in agent that answers prompts with patches
 }
 
 // DefaultConfig returns the default configuration
@@ -69,7 +275,37 @@ func DefaultConfig() *Config {
 		TemplatesDir:   "genesis/templates",
 		StartHereFile:  "genesis/START-HERE.md",
 		ChecklistFile:  "genesis/CHECKLIST.md",
+		StepsDir:       "genesis/steps",
+		ManifestFile:   "genesis/template-manifest.json",
+		BaselineDir:    "genesis/examples/hello-world",
+		ReferenceDocs:  DefaultReferenceDocs("genesis"),
+		Budgets:        DefaultBudgets("genesis"),
+		EntryPoints:    DefaultEntryPoints(),
+		GitHubRepo:     "bordenet/genesis",
+		DefaultBranch:  "main",
+		ExternalLinks:  DefaultExternalLinkConfig(),
+		Strict:         runningInCI(),
 		Verbose:        false,
 		GeneratePrompt: true,
+		PromptProfile:  DefaultPromptProfile,
+		PromptTokens:   DefaultPromptTokens,
+		Agent:          DefaultAgentConfig(),
+	}
+}
+
+// runningInCI reports whether the CI environment variable is set, as it is
+// on GitHub Actions, GitLab CI and most other CI services
+func runningInCI() bool {
+	ci := strings.ToLower(os.Getenv("CI"))
+	return ci != "" && ci != "false" && ci != "0"
+}
+
+// DefaultReferenceDocs returns the documents parsed for template references
+// under a genesis root: START-HERE.md, CHECKLIST.md and every step file
+func DefaultReferenceDocs(genesisRoot string) []string {
+	return []string{
+		genesisRoot + "/START-HERE.md",
+		genesisRoot + "/CHECKLIST.md",
+		genesisRoot + "/steps/*.md",
 	}
 }
@@ -1,8 +1,14 @@
 package validator
 
 import (
+	"context"
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // Validator validates Genesis template consistency
@@ -11,62 +17,221 @@ type Validator struct {
 	scanner       *Scanner
 	parser        *Parser
 	linkValidator *LinkValidator
+	ws            *workspace
+	log           *slog.Logger
+}
+
+// Options configures where a Validator reads from and reports progress to
+type Options struct {
+	FS     fs.FS        // Tree to validate instead of the repository on disk
+	Logger *slog.Logger // Receives progress messages; nil discards them
 }
 
 // NewValidator creates a new Validator
 func NewValidator(config *Config) *Validator {
+	return NewValidatorWith(config, Options{})
+}
+
+// NewValidatorWith creates a new Validator reading from opts.FS, when set,
+// and logging to opts.Logger
+func NewValidatorWith(config *Config, opts Options) *Validator {
+	ws, config := openWorkspace(config, opts.FS)
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(discardHandler{})
+	}
 	return &Validator{
 		config:        config,
-		scanner:       NewScanner(config),
-		parser:        NewParser(config),
-		linkValidator: NewLinkValidator(config),
+		scanner:       &Scanner{config: config, ws: ws},
+		parser:        &Parser{config: config, ws: ws},
+		linkValidator: newLinkValidator(config, ws),
+		ws:            ws,
+		log:           logger,
 	}
 }
 
+// discardHandler is a slog.Handler that drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
 // Validate performs comprehensive validation
 func (v *Validator) Validate() (*ValidationResult, error) {
-	result := &ValidationResult{
-		ReferencedFiles: make(map[string][]string),
+	return v.ValidateContext(context.Background())
+}
+
+// ValidateContext performs comprehensive validation, stopping with the
+// context's error and the findings so far once ctx is done
+func (v *Validator) ValidateContext(ctx context.Context) (*ValidationResult, error) {
+	result := newValidationResult()
+	result.RepoRoot = v.ws.root
+	result.readFile = v.ws.ReadFile
+
+	if err := v.validateTemplates(ctx, result); err != nil {
+		return result, err
+	}
+
+	// Step 6: Validate markdown links across all .md files
+	mdFiles, err := v.linkValidator.findMarkdownFiles(ctx)
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to find markdown files: %w", err))
+	}
+
+	brokenLinks, err := v.linkValidator.validateFiles(ctx, mdFiles)
+	if err != nil {
+		return result, err
+	}
+	addBrokenLinks(result, brokenLinks)
+	result.markChecked("broken_link")
+	v.log.Info("checked markdown links", "files", len(mdFiles), "broken", len(brokenLinks))
+
+	// Step 6a: Check paths use the exact case of tracked files and are portable
+	v.validatePortability(result, mdFiles)
+
+	// Step 6b: Check markdown files against their size budgets
+	result.markChecked("over_budget")
+	for _, file := range mdFiles {
+		inc, err := v.checkBudget(file)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("failed to check budget for %s: %w", file, err))
+		} else if inc != nil {
+			result.Inconsistencies = append(result.Inconsistencies, *inc)
+		}
+	}
+
+	// Step 6c: Check "Part of" breadcrumbs of split documents
+	breadcrumbs, err := v.validateBreadcrumbs(mdFiles)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to check breadcrumbs: %w", err))
+	} else {
+		result.markChecked("missing_breadcrumb", "unlinked_child")
 	}
+	result.Inconsistencies = append(result.Inconsistencies, breadcrumbs...)
 
+	// Step 6d: Find documents unreachable from the entry points
+	unreachable, err := v.findUnreachableDocs(mdFiles)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to build link graph: %w", err))
+	} else if len(v.entryPoints()) > 0 {
+		result.markChecked("unreachable_doc")
+	}
+	result.Inconsistencies = append(result.Inconsistencies, unreachable...)
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	// Step 6e: Check external URLs, only when enabled since it needs the network
+	if v.config.ExternalLinks.Enabled {
+		v.validateExternalLinks(ctx, result, mdFiles)
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+
+	// Step 7: Apply inline suppression comments
+	applySuppressions(result, mdFiles, func(_, rule string) bool { return result.checked[rule] }, v.ws.ReadFile)
+
+	result.Sort()
+	return result, nil
+}
+
+// validateExternalLinks reports dead external URLs as broken links and
+// possibly temporary failures as warnings
+func (v *Validator) validateExternalLinks(ctx context.Context, result *ValidationResult, mdFiles []string) {
+	external := v.config.ExternalLinks
+	if external.CacheFile != "" {
+		external.CacheFile = v.ws.Abs(external.CacheFile)
+	}
+	checker, err := NewExternalChecker(external)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+		return
+	}
+
+	broken, warnings, err := v.linkValidator.ValidateExternalLinks(ctx, checker, mdFiles)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+	} else {
+		result.markChecked("external_link_error")
+	}
+	addBrokenLinks(result, broken)
+	result.Inconsistencies = append(result.Inconsistencies, warnings...)
+}
+
+// validateTemplates runs the template inventory and reference checks (steps 1-5)
+func (v *Validator) validateTemplates(ctx context.Context, result *ValidationResult) error {
 	// Step 1: Scan for all template files (continue if templates dir doesn't exist)
-	templates, err := v.scanner.ScanTemplates()
+	templates, err := v.scanner.ScanTemplates(ctx)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	if err != nil {
 		// Only fail if it's not a "directory doesn't exist" error
 		if !os.IsNotExist(err) {
 			result.Errors = append(result.Errors, fmt.Errorf("failed to scan templates: %w", err))
-			return result, err
+			return err
 		}
-		// Templates dir doesn't exist - this is OK, continue with link validation
-		if v.config.Verbose {
-			fmt.Printf("Note: Templates directory not found, skipping template validation\n")
+		// Templates dir doesn't exist - fine when the hello-world baseline replaces it
+		if !v.hasBaseline() {
+			result.Errors = append(result.Errors, fmt.Errorf("neither templates directory %s nor baseline %s exists", v.config.TemplatesDir, v.config.BaselineDir))
+		} else {
+			v.log.Info("templates directory not found, checking baseline instead", "baseline", displayPath(v.config.BaselineDir))
 		}
 	}
-	result.TemplateFiles = templates
 
-	if v.config.Verbose {
-		fmt.Printf("Found %d template files\n", len(templates))
+	// Step 1a: Load the template manifest; listed templates count even when
+	// their names don't follow the naming convention
+	manifest, err := v.loadManifest()
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to load template manifest: %w", err))
+	}
+	if manifest != nil {
+		for _, entry := range manifest.Templates {
+			if entry.Path == "" || containsString(templates, entry.Path) {
+				continue
+			}
+			if v.ws.Exists(filepath.Join(v.config.GenesisRoot, entry.Path)) {
+				templates = append(templates, entry.Path)
+			}
+		}
 	}
+	result.TemplateFiles = templates
+
+	v.log.Info("found template files", "count", len(templates))
 
 	// Step 2: Parse documentation for references
 	docRefs, err := v.parser.ParseAllDocs()
 	if err != nil {
 		result.Errors = append(result.Errors, fmt.Errorf("failed to parse documentation: %w", err))
-		return result, err
+		return err
 	}
 
-	// Step 3: Build referenced files map
+	// Step 3: Build referenced files map (docs in sorted order so output is deterministic)
+	docs := make([]string, 0, len(docRefs))
+	for doc := range docRefs {
+		docs = append(docs, doc)
+	}
+	sort.Strings(docs)
+
 	referencedSet := make(map[string]bool)
-	for doc, refs := range docRefs {
-		for _, ref := range refs {
-			result.ReferencedFiles[ref] = append(result.ReferencedFiles[ref], doc)
-			referencedSet[ref] = true
+	for _, doc := range docs {
+		for _, ref := range docRefs[doc] {
+			if !referencedSet[ref.Path] || !containsString(result.ReferencedFiles[ref.Path], doc) {
+				result.ReferencedFiles[ref.Path] = append(result.ReferencedFiles[ref.Path], doc)
+			}
+			result.References[ref.Path] = append(result.References[ref.Path], ref)
+			referencedSet[ref.Path] = true
 		}
 	}
 
-	if v.config.Verbose {
-		fmt.Printf("Found %d unique references across documentation\n", len(referencedSet))
-	}
+	v.log.Info("found template references", "unique", len(referencedSet))
 
 	// Step 4: Find orphaned files (templates not referenced in docs)
 	for _, template := range templates {
@@ -86,43 +251,182 @@ func (v *Validator) Validate() (*ValidationResult, error) {
 		templateSet[template] = true
 	}
 
+	refs := make([]string, 0, len(referencedSet))
 	for ref := range referencedSet {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	for _, ref := range refs {
 		if !templateSet[ref] {
 			result.MissingFiles = append(result.MissingFiles, ref)
-			docs := result.ReferencedFiles[ref]
+			locations := make([]string, 0, len(result.References[ref]))
+			for _, loc := range result.References[ref] {
+				locations = append(locations, loc.String())
+			}
 			result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
 				Type:        "missing_file",
 				File:        ref,
 				Description: "Referenced in documentation but file does not exist",
-				Location:    fmt.Sprintf("Referenced in: %v", docs),
+				Location:    strings.Join(locations, ", "),
 			})
 		}
 	}
 
+	// Step 5a: Check the manifest against the files on disk and START-HERE
+	if manifest != nil {
+		v.validateManifest(result, manifest, templates)
+	}
+
+	// Step 5b: Check the hello-world baseline against copy and edit instructions
+	if v.hasBaseline() {
+		if err := v.validateInventory(ctx, result); err != nil {
+			return err
+		}
+	}
+
+	// Step 5c: Check the step files form a complete chain
+	if v.config.StepsDir != "" {
+		v.validateSteps(result)
+	}
+
 	// Note: Doc consistency check between START-HERE.md and CHECKLIST.md was removed
 	// because CHECKLIST.md is a high-level verification document that intentionally
 	// doesn't list every template file. START-HERE.md is the single source of truth
 	// for template references.
 
-	// Step 6: Validate markdown links across all .md files
-	brokenLinks, err := v.linkValidator.ValidateAllLinks()
+	return nil
+}
+
+// hasBaseline reports whether a hello-world baseline directory is configured and exists
+func (v *Validator) hasBaseline() bool {
+	if v.config.BaselineDir == "" {
+		return false
+	}
+	info, err := v.ws.Stat(v.config.BaselineDir)
+	return err == nil && info.IsDir()
+}
+
+// validateInventory reports baseline files no step copies and copy or edit
+// targets missing from the baseline. It returns only the context's error;
+// other failures are recorded in the result.
+func (v *Validator) validateInventory(ctx context.Context, result *ValidationResult) error {
+	orphaned, missing, err := (&Inventory{config: v.config, parser: v.parser, ws: v.ws}).Check(ctx)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	if err != nil {
-		result.Errors = append(result.Errors, fmt.Errorf("failed to validate links: %w", err))
-	} else {
-		result.BrokenLinks = brokenLinks
-		for _, link := range brokenLinks {
-			result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
-				Type:        "broken_link",
-				File:        link.SourceFile,
-				Description: link.Reason,
-				Location:    fmt.Sprintf("%s:%d", link.SourceFile, link.Line),
-			})
+		result.Errors = append(result.Errors, fmt.Errorf("failed to check baseline inventory: %w", err))
+		return nil
+	}
+
+	v.log.Info("checked baseline inventory", "uncopied", len(orphaned), "missing", len(missing))
+
+	for _, file := range orphaned {
+		result.OrphanedFiles = append(result.OrphanedFiles, file)
+		result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
+			Type:        "orphaned_file",
+			File:        file,
+			Description: "Baseline file is not copied by any step and not listed in notCopied",
+		})
+	}
+
+	var paths []string
+	for _, ref := range missing {
+		if _, seen := result.References[ref.Path]; !seen {
+			paths = append(paths, ref.Path)
+		}
+		result.References[ref.Path] = append(result.References[ref.Path], ref)
+		if !containsString(result.ReferencedFiles[ref.Path], ref.Doc) {
+			result.ReferencedFiles[ref.Path] = append(result.ReferencedFiles[ref.Path], ref.Doc)
 		}
 	}
+	for _, path := range paths {
+		result.MissingFiles = append(result.MissingFiles, path)
+		locations := make([]string, 0, len(result.References[path]))
+		for _, loc := range result.References[path] {
+			locations = append(locations, loc.String())
+		}
+		result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
+			Type:        "missing_file",
+			File:        path,
+			Description: "Copied or edited by the steps but does not exist in the baseline",
+			Location:    strings.Join(locations, ", "),
+		})
+	}
+
+	return nil
+}
+
+// ValidateStaged validates only the markdown links affected by changes staged
+// in the git index, for use from a pre-commit hook
+func (v *Validator) ValidateStaged(repo *GitRepo) (*ValidationResult, error) {
+	result := newValidationResult()
+	result.RepoRoot = v.ws.root
+	result.readFile = v.ws.ReadFile
+
+	brokenLinks, err := v.linkValidator.ValidateStagedLinks(repo)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("failed to validate staged links: %w", err))
+		return result, err
+	}
+	addBrokenLinks(result, brokenLinks)
+
+	v.log.Info("checked staged links", "broken", len(brokenLinks))
 
-	if v.config.Verbose {
-		fmt.Printf("Found %d broken links\n", len(brokenLinks))
+	// Suppressions are read from staged content. Files only re-checked for
+	// inbound links can't have their unused suppressions judged.
+	changes, err := repo.StagedChanges()
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+		return result, err
+	}
+	changed := make(map[string]bool)
+	for _, change := range changes {
+		if change.Status != 'D' && strings.HasSuffix(change.Path, ".md") {
+			changed[change.Path] = true
+		}
+	}
+	files := make(map[string]bool)
+	for file := range changed {
+		files[file] = true
+	}
+	for _, link := range brokenLinks {
+		files[link.SourceFile] = true
+	}
+	sources := make([]string, 0, len(files))
+	for file := range files {
+		sources = append(sources, file)
 	}
+	sort.Strings(sources)
+	applySuppressions(result, sources, func(file, rule string) bool {
+		return changed[file] && rule == "broken_link" // The only rule staged mode runs
+	}, repo.ReadStaged)
 
+	result.Sort()
 	return result, nil
 }
+
+// addBrokenLinks records broken links on the result and as inconsistencies
+func addBrokenLinks(result *ValidationResult, brokenLinks []BrokenLink) {
+	result.BrokenLinks = append(result.BrokenLinks, brokenLinks...)
+	for _, link := range brokenLinks {
+		result.Inconsistencies = append(result.Inconsistencies, Inconsistency{
+			Type:        link.findingType(),
+			File:        link.SourceFile,
+			Line:        link.Line,
+			Description: link.Reason,
+			Location:    fmt.Sprintf("%s:%d", link.SourceFile, link.Line),
+		})
+	}
+}
+
+// containsString reports whether values contains v
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -56,6 +56,7 @@ cp genesis/templates/CLAUDE.md.template CLAUDE.md
 	}
 
 	config := &Config{
+		RepoRoot:      tmpDir,
 		GenesisRoot:   genesisDir,
 		TemplatesDir:  templatesDir,
 		StartHereFile: startHereFile,
@@ -336,3 +337,119 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("Expected ChecklistFile 'genesis/CHECKLIST.md', got '%s'", config.ChecklistFile)
 	}
 }
+
+func TestValidator_Validate_MissingFileLocation(t *testing.T) {
+	tmpDir, config := setupTestEnvironment(t)
+	chdir(t, tmpDir)
+
+	startHereFile := filepath.Join(tmpDir, "genesis/START-HERE.md")
+	content, _ := os.ReadFile(startHereFile)
+	if err := os.WriteFile(startHereFile, append(content, []byte("cp genesis/templates/gone-template.js gone.js\n")...), 0644); err != nil {
+		t.Fatalf("Failed to update START-HERE.md: %v", err)
+	}
+
+	result, err := NewValidator(config).Validate()
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	refs := result.References["templates/gone-template.js"]
+	if len(refs) != 1 || refs[0].Doc != "genesis/START-HERE.md" || refs[0].Line != 7 {
+		t.Errorf("unexpected references: %+v", refs)
+	}
+
+	for _, inc := range result.Inconsistencies {
+		if inc.Type == "missing_file" && inc.Location != "genesis/START-HERE.md:7" {
+			t.Errorf("missing_file Location = %q, want genesis/START-HERE.md:7", inc.Location)
+		}
+	}
+}
+
+func TestValidateLink_Strict(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "docs/guide/setup.md", "# Setup\n")
+	writeTestFile(t, tmpDir, "docs/intro.md", "# Intro\n")
+	writeTestFile(t, tmpDir, "README.md", "# Repo\n")
+	chdir(t, tmpDir)
+
+	tests := []struct {
+		source     string
+		url        string
+		wantLoose  string
+		wantStrict string
+	}{
+		{"docs/guide/setup.md", "../intro.md", "", ""},
+		{"docs/guide/setup.md", "docs/intro.md#why", "", "Resolves only from the repository root, which GitHub renders as 404; use ../intro.md#why"},
+		{"docs/intro.md", "README.md", "", "Resolves only from the repository root, which GitHub renders as 404; use ../README.md"},
+		{"docs/intro.md", "../../" + filepath.Base(tmpDir) + "/README.md", "", "Link escapes the repository root: ../../" + filepath.Base(tmpDir) + "/README.md"},
+		{"docs/intro.md", "missing.md", "Relative path not found: missing.md", "Relative path not found: missing.md"},
+	}
+
+	for _, strict := range []bool{false, true} {
+		config := DefaultConfig()
+		config.Strict = strict
+		lv := NewLinkValidator(config)
+
+		for _, tt := range tests {
+			want := tt.wantLoose
+			if strict {
+				want = tt.wantStrict
+			}
+			got := ""
+			if broken := lv.validateLink(tt.source, linkInfo{url: tt.url, line: 1}); broken != nil {
+				got = broken.Reason
+			}
+			if got != want {
+				t.Errorf("strict=%v validateLink(%s, %s) reason = %q, want %q", strict, tt.source, tt.url, got, want)
+			}
+		}
+	}
+}
+
+func TestValidateLink_SuggestsFix(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "docs/guide/setup.md", "# Setup\n")
+	writeTestFile(t, tmpDir, "docs/intro.md", "# Intro\n")
+	chdir(t, tmpDir)
+
+	config := DefaultConfig()
+	config.Strict = true
+	lv := NewLinkValidator(config)
+
+	tests := []struct {
+		source  string
+		url     string
+		wantNew string
+	}{
+		{"docs/guide/setup.md", "docs/intro.md#why", "](../intro.md#why)"}, // Root-relative
+		{"docs/intro.md", "setup.md", "](guide/setup.md)"},                 // Unique file name
+		{"docs/intro.md", "missing.md", ""},
+	}
+	for _, tt := range tests {
+		broken := lv.validateLink(tt.source, linkInfo{url: tt.url, line: 1})
+		if broken == nil {
+			t.Fatalf("validateLink(%s, %s) = nil, want broken", tt.source, tt.url)
+		}
+		got := ""
+		if broken.Fix != nil {
+			got = broken.Fix.New
+			if broken.Fix.Old != "]("+tt.url+")" {
+				t.Errorf("validateLink(%s, %s) fix replaces %q", tt.source, tt.url, broken.Fix.Old)
+			}
+		}
+		if got != tt.wantNew {
+			t.Errorf("validateLink(%s, %s) fix = %q, want %q", tt.source, tt.url, got, tt.wantNew)
+		}
+	}
+}
+
+func TestDefaultConfig_StrictInCI(t *testing.T) {
+	t.Setenv("CI", "true")
+	if !DefaultConfig().Strict {
+		t.Error("DefaultConfig().Strict = false with CI=true, want true")
+	}
+	t.Setenv("CI", "false")
+	if DefaultConfig().Strict {
+		t.Error("DefaultConfig().Strict = true with CI=false, want false")
+	}
+}
@@ -0,0 +1,392 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Watcher re-runs validation whenever markdown files or the genesis root change.
+// It polls file metadata rather than relying on platform file notifications.
+type Watcher struct {
+	config    *Config
+	validator *Validator
+	out       io.Writer
+
+	Interval time.Duration // How often to poll for changes
+	Debounce time.Duration // Quiet period required before re-running checks
+
+	snapshot  map[string]fileStamp
+	templates []Inconsistency          // Findings from the template checks
+	rules     map[string]bool          // Rules the template checks evaluated
+	links     map[string][]BrokenLink  // Broken links keyed by source file
+	budgets   map[string]Inconsistency // Over-budget findings keyed by file
+	crumbs    []Inconsistency          // Findings from the breadcrumb and reachability checks
+	errs      []error
+	findings  map[string][]Inconsistency // Findings reported by the previous run, keyed by fingerprint
+	total     int                        // Number of findings reported by the previous run
+}
+
+// fileStamp records the metadata used to detect a changed file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher creates a new Watcher that reports to out
+func NewWatcher(config *Config, out io.Writer) *Watcher {
+	v := NewValidator(config)
+	return &Watcher{
+		config:    v.config,
+		validator: v,
+		out:       out,
+		Interval:  500 * time.Millisecond,
+		Debounce:  300 * time.Millisecond,
+		links:     make(map[string][]BrokenLink),
+		budgets:   make(map[string]Inconsistency),
+	}
+}
+
+// Run validates once, then watches for changes until stop is closed
+func (w *Watcher) Run(stop <-chan struct{}) error {
+	snapshot, err := w.takeSnapshot()
+	if err != nil {
+		return err
+	}
+	w.snapshot = snapshot
+
+	result := w.runChecks(nil)
+	fmt.Fprintln(w.out, result.Summary())
+	w.report(result)
+	fmt.Fprintf(w.out, "👀 Watching %d files for changes (Ctrl+C to stop)...\n", len(w.snapshot))
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	var pending map[string]bool
+	var lastChange time.Time
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case now := <-ticker.C:
+			snapshot, err := w.takeSnapshot()
+			if err != nil {
+				return err
+			}
+
+			if changed := changedPaths(w.snapshot, snapshot); len(changed) > 0 {
+				if pending == nil {
+					pending = make(map[string]bool)
+				}
+				for _, path := range changed {
+					pending[path] = true
+				}
+				lastChange = now
+			}
+			w.snapshot = snapshot
+
+			// Wait for edits to settle before re-running checks
+			if pending == nil || now.Sub(lastChange) < w.Debounce {
+				continue
+			}
+
+			changed := make([]string, 0, len(pending))
+			for path := range pending {
+				changed = append(changed, path)
+			}
+			sort.Strings(changed)
+			pending = nil
+
+			fmt.Fprintf(w.out, "\n🔄 %d file(s) changed: %s\n", len(changed), strings.Join(changed, ", "))
+			w.report(w.runChecks(changed))
+		}
+	}
+}
+
+// takeSnapshot records the metadata of every watched file
+func (w *Watcher) takeSnapshot() (map[string]fileStamp, error) {
+	snapshot := make(map[string]fileStamp)
+
+	record := func(path string, info fs.FileInfo) {
+		snapshot[filepath.ToSlash(filepath.Clean(path))] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	mdFiles, err := w.validator.linkValidator.findMarkdownFiles(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range mdFiles {
+		if info, err := w.validator.ws.Stat(path); err == nil {
+			record(path, info)
+		}
+	}
+
+	err = w.validator.ws.Walk(context.Background(), w.config.GenesisRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Genesis root may be missing or mid-edit
+		}
+		if d.IsDir() {
+			if isExcludedDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			record(path, info)
+		}
+		return nil
+	})
+
+	return snapshot, err
+}
+
+// changedPaths returns paths added, removed or modified between two snapshots
+func changedPaths(before, after map[string]fileStamp) []string {
+	var changed []string
+	for path, stamp := range after {
+		if old, ok := before[path]; !ok || !old.modTime.Equal(stamp.modTime) || old.size != stamp.size {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// runChecks re-runs the checks affected by the changed paths and returns the
+// combined result. A nil changed list runs every check.
+func (w *Watcher) runChecks(changed []string) *ValidationResult {
+	full := changed == nil
+	w.errs = nil
+
+	if full || w.affectsTemplates(changed) {
+		result := newValidationResult()
+		_ = w.validator.validateTemplates(context.Background(), result)
+		w.templates = result.Inconsistencies
+		w.rules = result.checked
+		w.errs = append(w.errs, result.Errors...)
+	}
+
+	if full || changesMarkdown(changed) {
+		mdFiles, err := w.validator.linkValidator.findMarkdownFiles(context.Background())
+		if err == nil {
+			w.crumbs, err = w.validator.validateBreadcrumbs(mdFiles)
+		}
+		if err != nil {
+			w.errs = append(w.errs, fmt.Errorf("failed to check breadcrumbs: %w", err))
+		}
+		if unreachable, err := w.validator.findUnreachableDocs(mdFiles); err != nil {
+			w.errs = append(w.errs, fmt.Errorf("failed to build link graph: %w", err))
+		} else {
+			w.crumbs = append(w.crumbs, unreachable...)
+		}
+	}
+
+	for _, file := range w.affectedLinkSources(changed, full) {
+		if !w.validator.ws.Exists(file) {
+			delete(w.links, file)
+			delete(w.budgets, file)
+			continue
+		}
+		if inc, err := w.validator.checkBudget(file); err != nil {
+			w.errs = append(w.errs, err)
+		} else if inc != nil {
+			w.budgets[file] = *inc
+		} else {
+			delete(w.budgets, file)
+		}
+		broken, err := w.validator.linkValidator.ValidateFileLinks(file)
+		if err != nil {
+			w.errs = append(w.errs, fmt.Errorf("failed to validate links in %s: %w", file, err))
+			continue
+		}
+		w.links[file] = broken
+	}
+
+	return w.result()
+}
+
+// changesMarkdown reports whether any changed path is a markdown file
+func changesMarkdown(changed []string) bool {
+	for _, path := range changed {
+		if strings.HasSuffix(path, ".md") {
+			return true
+		}
+	}
+	return false
+}
+
+// affectsTemplates reports whether any changed path feeds the template checks
+func (w *Watcher) affectsTemplates(changed []string) bool {
+	templatesDir := filepath.ToSlash(filepath.Clean(w.config.TemplatesDir)) + "/"
+	baselineDir := filepath.ToSlash(filepath.Clean(w.config.BaselineDir)) + "/"
+	patterns := w.config.ReferenceDocs
+	if len(patterns) == 0 {
+		patterns = []string{w.config.StartHereFile, w.config.ChecklistFile}
+	}
+	patterns = patterns[:len(patterns):len(patterns)]
+	if w.config.ManifestFile != "" {
+		patterns = append(patterns, w.config.ManifestFile)
+	}
+	if w.config.StepsDir != "" {
+		patterns = append(patterns, filepath.Join(w.config.StepsDir, "*.md"))
+	}
+
+	for _, path := range changed {
+		if strings.HasPrefix(path, templatesDir) || (w.config.BaselineDir != "" && strings.HasPrefix(path, baselineDir)) {
+			return true
+		}
+		for _, pattern := range patterns {
+			if matched, _ := filepath.Match(filepath.ToSlash(filepath.Clean(pattern)), path); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// affectedLinkSources returns the markdown files whose links must be re-checked:
+// every changed markdown file plus every file linking to a changed path
+func (w *Watcher) affectedLinkSources(changed []string, full bool) []string {
+	mdFiles, err := w.validator.linkValidator.findMarkdownFiles(context.Background())
+	if err != nil {
+		w.errs = append(w.errs, err)
+		return nil
+	}
+	for i, file := range mdFiles {
+		mdFiles[i] = filepath.ToSlash(filepath.Clean(file))
+	}
+
+	if full {
+		w.links = make(map[string][]BrokenLink)
+		return mdFiles
+	}
+
+	sources := make(map[string]bool)
+	for _, path := range changed {
+		if strings.HasSuffix(path, ".md") {
+			sources[path] = true
+		}
+	}
+
+	index := w.validator.linkValidator.BuildLinkIndex(mdFiles, w.validator.ws.ReadFile)
+	for _, path := range changed {
+		for _, inbound := range index.Inbound(path) {
+			sources[inbound.SourceFile] = true
+		}
+	}
+
+	files := make([]string, 0, len(sources))
+	for file := range sources {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// result assembles the current findings into a ValidationResult
+func (w *Watcher) result() *ValidationResult {
+	result := newValidationResult()
+	result.RepoRoot = w.validator.ws.root
+	result.readFile = w.validator.ws.ReadFile
+	result.Inconsistencies = append(result.Inconsistencies, w.templates...)
+	result.Inconsistencies = append(result.Inconsistencies, w.crumbs...)
+	result.Errors = w.errs
+	for _, inc := range w.templates {
+		switch inc.Type {
+		case "orphaned_file":
+			result.OrphanedFiles = append(result.OrphanedFiles, inc.File)
+		case "missing_file":
+			result.MissingFiles = append(result.MissingFiles, inc.File)
+		}
+	}
+
+	files := make([]string, 0, len(w.links))
+	for file := range w.links {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		addBrokenLinks(result, w.links[file])
+		if inc, ok := w.budgets[file]; ok {
+			result.Inconsistencies = append(result.Inconsistencies, inc)
+		}
+	}
+	// Portability and external links aren't watched, so their suppressions can't be judged
+	for rule := range w.rules {
+		result.markChecked(rule)
+	}
+	result.markChecked("broken_link", "over_budget", "missing_breadcrumb", "unlinked_child")
+	if len(w.validator.entryPoints()) > 0 {
+		result.markChecked("unreachable_doc")
+	}
+	applySuppressions(result, files, func(_, rule string) bool { return result.checked[rule] }, w.validator.ws.ReadFile)
+
+	return result
+}
+
+// diff compares the result with the previous run and records it as the new
+// baseline. Findings are matched by fingerprint, so a finding that only moved
+// to another line is neither new nor resolved.
+func (w *Watcher) diff(result *ValidationResult) (appeared, resolved []Inconsistency) {
+	fingerprints := newFingerprinter(result)
+	current := make(map[string][]Inconsistency, len(result.Inconsistencies))
+	for _, inc := range result.Inconsistencies {
+		fp := fingerprints.of(inc)
+		current[fp] = append(current[fp], inc)
+		if len(w.findings[fp]) > 0 {
+			w.findings[fp] = w.findings[fp][1:]
+		} else {
+			appeared = append(appeared, inc)
+		}
+	}
+	for _, previous := range w.findings {
+		resolved = append(resolved, previous...)
+	}
+	sortInconsistencies(resolved)
+	w.findings = current
+	w.total = len(result.Inconsistencies)
+
+	return appeared, resolved
+}
+
+// report prints errors from the latest run and a concise list of findings
+// that appeared or were resolved since the previous run
+func (w *Watcher) report(result *ValidationResult) {
+	for _, err := range result.Errors {
+		fmt.Fprintf(w.out, "  ❌ %v\n", err)
+	}
+
+	appeared, resolved := w.diff(result)
+	if len(appeared) == 0 && len(resolved) == 0 {
+		fmt.Fprintln(w.out, "  No change in findings")
+		return
+	}
+	for _, inc := range appeared {
+		fmt.Fprintf(w.out, "  + [%s] %s\n", inc.Type, describeInconsistency(inc))
+	}
+	for _, inc := range resolved {
+		fmt.Fprintf(w.out, "  - [%s] %s\n", inc.Type, describeInconsistency(inc))
+	}
+	fmt.Fprintf(w.out, "  %d new, %d resolved, %d total\n", len(appeared), len(resolved), w.total)
+}
+
+// describeInconsistency formats an inconsistency as a single line
+func describeInconsistency(inc Inconsistency) string {
+	location := inc.File
+	if strings.HasPrefix(inc.Location, inc.File+":") {
+		location = inc.Location
+	}
+	return fmt.Sprintf("%s: %s", location, inc.Description)
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// chdir changes into dir for the duration of the test
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestChangedPaths(t *testing.T) {
+	now := time.Now()
+	before := map[string]fileStamp{
+		"same.md":     {modTime: now, size: 1},
+		"modified.md": {modTime: now, size: 1},
+		"removed.md":  {modTime: now, size: 1},
+	}
+	after := map[string]fileStamp{
+		"same.md":     {modTime: now, size: 1},
+		"modified.md": {modTime: now.Add(time.Second), size: 1},
+		"added.md":    {modTime: now, size: 1},
+	}
+
+	got := changedPaths(before, after)
+	want := []string{"added.md", "modified.md", "removed.md"}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("changedPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestWatcher_RunChecksReportsAppearedAndResolved(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "genesis/START-HERE.md", "# Start\n\n[Checklist](CHECKLIST.md) [A](../docs/a.md) [C](../docs/c.md)\n")
+	writeTestFile(t, dir, "genesis/CHECKLIST.md", "# Checklist\n")
+	writeTestFile(t, dir, "docs/a.md", "[B](b.md)\n")
+	writeTestFile(t, dir, "docs/b.md", "# B\n")
+	writeTestFile(t, dir, "docs/c.md", "[Missing](missing.md)\n")
+	chdir(t, dir)
+
+	var out bytes.Buffer
+	w := NewWatcher(DefaultConfig(), &out)
+
+	appeared, resolved := w.diff(w.runChecks(nil))
+	if len(appeared) != 1 || len(resolved) != 0 {
+		t.Fatalf("initial run: appeared=%v resolved=%v, want 1 appeared", appeared, resolved)
+	}
+
+	// Deleting b.md breaks the inbound link from a.md
+	if err := os.Remove("docs/b.md"); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+	appeared, resolved = w.diff(w.runChecks([]string{"docs/b.md"}))
+	if len(appeared) != 1 || appeared[0].File != "docs/a.md" || len(resolved) != 0 {
+		t.Errorf("after delete: appeared=%v resolved=%v", appeared, resolved)
+	}
+
+	// Fixing c.md resolves its finding without re-reporting a.md
+	writeTestFile(t, dir, "docs/c.md", "[A](a.md)\n")
+	appeared, resolved = w.diff(w.runChecks([]string{"docs/c.md"}))
+	if len(appeared) != 0 || len(resolved) != 1 || resolved[0].File != "docs/c.md" {
+		t.Errorf("after fix: appeared=%v resolved=%v", appeared, resolved)
+	}
+
+	if got := len(w.result().BrokenLinks); got != 1 {
+		t.Errorf("result() has %d broken links, want 1", got)
+	}
+}
+
+func TestWatcher_ReportPrintsDiff(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWatcher(DefaultConfig(), &out)
+
+	result := &ValidationResult{}
+	addBrokenLinks(result, []BrokenLink{{SourceFile: "a.md", Line: 3, LinkURL: "b.md", Reason: "Relative path not found: b.md"}})
+	w.report(result)
+
+	if !strings.Contains(out.String(), "+ [broken_link] a.md:3: Relative path not found: b.md") {
+		t.Errorf("report() output missing appeared finding:\n%s", out.String())
+	}
+
+	out.Reset()
+	w.report(&ValidationResult{})
+	if !strings.Contains(out.String(), "- [broken_link] a.md:3") || !strings.Contains(out.String(), "0 new, 1 resolved") {
+		t.Errorf("report() output missing resolved finding:\n%s", out.String())
+	}
+}
+
+func TestWatcher_LineShiftIsNoChange(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "genesis/START-HERE.md", "# Start\n\n[Checklist](CHECKLIST.md) [C](../docs/c.md)\n")
+	writeTestFile(t, dir, "genesis/CHECKLIST.md", "# Checklist\n")
+	writeTestFile(t, dir, "docs/c.md", "# C\n\n[Missing](missing.md)\n[Gone](gone.md)\n")
+	chdir(t, dir)
+
+	var out bytes.Buffer
+	w := NewWatcher(DefaultConfig(), &out)
+	w.report(w.runChecks(nil))
+	if !strings.Contains(out.String(), "2 new, 0 resolved, 2 total") {
+		t.Fatalf("initial report:\n%s", out.String())
+	}
+
+	// A line inserted at the top moves both findings down without changing them
+	writeTestFile(t, dir, "docs/c.md", "<!-- intro -->\n# C\n\n[Missing](missing.md)\n[Gone](gone.md)\n")
+	out.Reset()
+	w.report(w.runChecks([]string{"docs/c.md"}))
+	if strings.TrimSpace(out.String()) != "No change in findings" {
+		t.Errorf("report after a line shift:\n%s", out.String())
+	}
+}
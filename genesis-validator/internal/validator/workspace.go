@@ -0,0 +1,215 @@
+package validator
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FindRepoRoot returns the nearest directory at or above start containing
+// .git, or start itself when no directory above it is a git working tree
+func FindRepoRoot(start string) (string, error) {
+	start, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	for dir := start; ; {
+		// .git is a file in worktrees and submodules
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return start, nil
+		}
+		dir = parent
+	}
+}
+
+// repoRoot returns the configured repository root, or the one found above
+// the working directory
+func repoRoot(config *Config) string {
+	if config.RepoRoot != "" {
+		if root, err := filepath.Abs(config.RepoRoot); err == nil {
+			return root
+		}
+		return config.RepoRoot
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	root, err := FindRepoRoot(wd)
+	if err != nil {
+		return wd
+	}
+	return root
+}
+
+// workspace reads the files of the repository being validated. Relative
+// paths are resolved from the repository root rather than the working
+// directory; absolute paths inside the root are treated as repo-relative and
+// those outside it are read from disk as is. A workspace over a caller's
+// fs.FS has no root on disk, so it sees nothing outside the FS and no git.
+type workspace struct {
+	root string // Absolute repository root on disk; "" for a virtual tree
+	fsys fs.FS
+}
+
+// newWorkspace opens the repository rooted at root
+func newWorkspace(root string) *workspace {
+	return &workspace{root: root, fsys: os.DirFS(root)}
+}
+
+// openWorkspace opens the repository a config describes, or the tree in fsys
+// when it is not nil, and returns the config with its paths made repo-relative
+func openWorkspace(config *Config, fsys fs.FS) (*workspace, *Config) {
+	ws := &workspace{fsys: fsys}
+	if fsys == nil {
+		ws = newWorkspace(repoRoot(config))
+	}
+	return ws, ws.anchorConfig(config)
+}
+
+// rel returns p relative to the repository root, or false when p lies
+// outside it
+func (w *workspace) rel(p string) (string, bool) {
+	if filepath.IsAbs(p) {
+		if w.root == "" {
+			return "", false
+		}
+		r, err := filepath.Rel(w.root, p)
+		if err != nil {
+			return "", false
+		}
+		p = r
+	}
+	p = filepath.Clean(p)
+	if p == ".." || strings.HasPrefix(p, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return p, true
+}
+
+// Anchor makes an absolute path inside the repository repo-relative and
+// leaves every other path unchanged
+func (w *workspace) Anchor(p string) string {
+	if p == "" || !filepath.IsAbs(p) {
+		return p
+	}
+	if r, ok := w.rel(p); ok {
+		return r
+	}
+	return p
+}
+
+// anchorConfig returns a copy of config with the paths it reads from made
+// repo-relative
+func (w *workspace) anchorConfig(config *Config) *Config {
+	anchored := *config
+	if w.root != "" {
+		anchored.RepoRoot = w.root
+	}
+	for _, p := range []*string{
+		&anchored.GenesisRoot, &anchored.TemplatesDir, &anchored.StartHereFile, &anchored.ChecklistFile,
+		&anchored.StepsDir, &anchored.ManifestFile, &anchored.BaselineDir, &anchored.ExternalLinks.CacheFile,
+	} {
+		*p = w.Anchor(*p)
+	}
+	if config.ReferenceDocs != nil {
+		anchored.ReferenceDocs = make([]string, len(config.ReferenceDocs))
+		for i, pattern := range config.ReferenceDocs {
+			anchored.ReferenceDocs[i] = w.Anchor(pattern)
+		}
+	}
+	return &anchored
+}
+
+// Abs returns the on-disk path of p
+func (w *workspace) Abs(p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(w.root, p)
+}
+
+// locate returns the file system holding p, the name of p within it and the
+// prefix that turns names found there back into paths of the form callers use
+func (w *workspace) locate(p string) (fs.FS, string, string) {
+	if r, ok := w.rel(p); ok {
+		return w.fsys, filepath.ToSlash(r), ""
+	}
+	if w.root == "" {
+		return w.fsys, filepath.ToSlash(p), "" // An invalid name, which fs.FS rejects
+	}
+	abs := w.Abs(p)
+	volume := filepath.VolumeName(abs) + string(filepath.Separator)
+	return os.DirFS(volume), filepath.ToSlash(strings.TrimPrefix(abs, volume)), volume
+}
+
+// ReadFile reads the file at p
+func (w *workspace) ReadFile(p string) ([]byte, error) {
+	fsys, name, _ := w.locate(p)
+	return fs.ReadFile(fsys, name)
+}
+
+// Open opens the file at p for reading
+func (w *workspace) Open(p string) (fs.File, error) {
+	fsys, name, _ := w.locate(p)
+	return fsys.Open(name)
+}
+
+// Stat describes the file at p
+func (w *workspace) Stat(p string) (fs.FileInfo, error) {
+	fsys, name, _ := w.locate(p)
+	return fs.Stat(fsys, name)
+}
+
+// Exists reports whether p exists
+func (w *workspace) Exists(p string) bool {
+	_, err := w.Stat(p)
+	return err == nil
+}
+
+// ReadDir lists the directory at p
+func (w *workspace) ReadDir(p string) ([]fs.DirEntry, error) {
+	fsys, name, _ := w.locate(p)
+	return fs.ReadDir(fsys, name)
+}
+
+// Walk walks the tree at root like filepath.WalkDir, passing repo-relative
+// paths inside the repository and absolute paths outside it. It stops with
+// the context's error once ctx is done.
+func (w *workspace) Walk(ctx context.Context, root string, fn fs.WalkDirFunc) error {
+	fsys, name, prefix := w.locate(root)
+	return fs.WalkDir(fsys, name, func(p string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fn(filepath.Join(prefix, filepath.FromSlash(p)), d, err)
+	})
+}
+
+// Glob returns the paths matching pattern, repo-relative inside the
+// repository and absolute outside it
+func (w *workspace) Glob(pattern string) ([]string, error) {
+	fsys, name, prefix := w.locate(pattern)
+	matches, err := fs.Glob(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	for i, match := range matches {
+		matches[i] = filepath.Join(prefix, filepath.FromSlash(match))
+	}
+	return matches, nil
+}
+
+// Git returns the git repository at the root, or nil for a virtual tree
+func (w *workspace) Git() *GitRepo {
+	if w.root == "" {
+		return nil
+	}
+	return NewGitRepo(w.root)
+}
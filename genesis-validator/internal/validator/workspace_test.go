@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindRepoRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "repo/.git/HEAD", "ref: refs/heads/main\n")
+	writeTestFile(t, tmpDir, "repo/tools/cli/main.go", "package main\n")
+	writeTestFile(t, tmpDir, "repo/worktree/.git", "gitdir: ../.git/worktrees/wt\n")
+	writeTestFile(t, tmpDir, "plain/docs/a.md", "# A\n")
+
+	tests := []struct {
+		start string
+		want  string
+	}{
+		{"repo", "repo"},
+		{"repo/tools/cli", "repo"},
+		{"repo/worktree", "repo/worktree"}, // .git may be a file
+		{"plain/docs", "plain/docs"},       // No repository: the start directory
+	}
+	for _, tt := range tests {
+		got, err := FindRepoRoot(filepath.Join(tmpDir, tt.start))
+		if err != nil {
+			t.Fatalf("FindRepoRoot(%s) error = %v", tt.start, err)
+		}
+		if want := filepath.Join(tmpDir, tt.want); got != want {
+			t.Errorf("FindRepoRoot(%s) = %s, want %s", tt.start, got, want)
+		}
+	}
+}
+
+func TestValidate_FromSubdirectory(t *testing.T) {
+	dir := setupGitRepo(t, map[string]string{
+		"README.md":               "# Repo\n\n[Tool](tools/cli/README.md)\n[Gone](docs/missing.md)\n",
+		"tools/cli/README.md":     "# CLI\n\n[Up](../../README.md)\n[Bad](nope.md)\n",
+		"genesis/START-HERE.md":   "# Start\n",
+		"genesis/CHECKLIST.md":    "# Checklist\n",
+		"genesis/templates/x.txt": "",
+	})
+
+	brokenLinks := func(config *Config) []string {
+		t.Helper()
+		config.EntryPoints = nil
+		result, err := NewValidator(config).Validate()
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		var links []string
+		for _, link := range result.BrokenLinks {
+			links = append(links, link.SourceFile+" -> "+link.LinkURL)
+		}
+		return links
+	}
+	want := []string{"README.md -> docs/missing.md", "tools/cli/README.md -> nope.md"}
+
+	chdir(t, filepath.Join(dir, "tools", "cli"))
+	if got := brokenLinks(DefaultConfig()); !reflect.DeepEqual(got, want) {
+		t.Errorf("broken links from a subdirectory = %v, want %v", got, want)
+	}
+
+	chdir(t, t.TempDir())
+	config := DefaultConfig()
+	config.RepoRoot = dir
+	if got := brokenLinks(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("broken links with RepoRoot = %v, want %v", got, want)
+	}
+}
+
+func TestLoadConfigFile_RelativeRepoRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestFile(t, tmpDir, "config/validator.json", `{"repoRoot": "..", "genesisRoot": "docs"}`)
+
+	config := DefaultConfig()
+	config.RepoRoot = "/elsewhere"
+	if err := LoadConfigFile(filepath.Join(tmpDir, "config", "validator.json"), config); err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	if config.RepoRoot != tmpDir {
+		t.Errorf("RepoRoot = %s, want %s", config.RepoRoot, tmpDir)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "config", "validator.json"), []byte(`{"verbose": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfigFile(filepath.Join(tmpDir, "config", "validator.json"), config); err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	if config.RepoRoot != tmpDir {
+		t.Errorf("RepoRoot without repoRoot in the file = %s, want it kept as %s", config.RepoRoot, tmpDir)
+	}
+}
@@ -0,0 +1,93 @@
+// Package validate checks Genesis template and documentation consistency
+// from Go programs. It never writes to stdout or stderr: progress goes to an
+// optional slog.Logger and results come back as structured findings.
+package validate
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+
+	"github.com/bordenet/genesis/genesis-validator/internal/validator"
+)
+
+// Config selects the files and checks to validate. Paths are relative to the
+// repository root, or to the root of Options.FS.
+type Config = validator.Config
+
+// Budget is a size limit for the markdown files matching a glob
+type Budget = validator.Budget
+
+// ExternalLinkConfig controls the opt-in check of external http(s) links
+type ExternalLinkConfig = validator.ExternalLinkConfig
+
+// Finding is one problem found by validation
+type Finding = validator.Finding
+
+// Severity ranks a finding: errors fail validation, warnings don't
+type Severity = validator.Severity
+
+const (
+	SeverityError   = validator.SeverityError
+	SeverityWarning = validator.SeverityWarning
+)
+
+// DefaultConfig returns the configuration the genesis-validator CLI uses,
+// except that strict mode is off. Only the CLI turns it on when CI is set, so
+// a library run doesn't change behavior with its environment.
+func DefaultConfig() *Config {
+	config := validator.DefaultConfig()
+	config.Strict = false
+	return config
+}
+
+// Options configures a validation run
+type Options struct {
+	Config *Config      // nil for DefaultConfig()
+	FS     fs.FS        // Tree to validate; nil reads Config.RepoRoot, or the repository above the working directory, from disk
+	Logger *slog.Logger // Receives progress messages; nil discards them
+}
+
+// Report is the outcome of a validation run
+type Report struct {
+	Findings      []Finding // Sorted by file and line
+	TemplateFiles []string  // Templates found, relative to the genesis root
+	Errors        []error   // Checks that could not run
+}
+
+// Valid reports whether every check ran and none found an error
+func (r *Report) Valid() bool {
+	if len(r.Errors) > 0 {
+		return false
+	}
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// Run validates a repository or tree. Git-based checks (tracked-file
+// discovery, path portability, GitHub links to other refs) only run when
+// validating a git working tree on disk. When ctx is done, Run stops and
+// returns the findings so far with the context's error.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	config := opts.Config
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	v := validator.NewValidatorWith(config, validator.Options{FS: opts.FS, Logger: opts.Logger})
+	result, err := v.ValidateContext(ctx)
+	if result == nil {
+		return nil, err
+	}
+	result.Sort()
+
+	return &Report{
+		Findings:      result.Findings(),
+		TemplateFiles: result.TemplateFiles,
+		Errors:        result.Errors,
+	}, err
+}
@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// testTree is a small genesis repository with one orphaned template and one
+// broken link
+func testTree() fstest.MapFS {
+	return fstest.MapFS{
+		"README.md":             {Data: []byte("# Repo\n\n[Start](genesis/START-HERE.md)\n[Gone](docs/missing.md)\n")},
+		"genesis/START-HERE.md": {Data: []byte("# Start\n\ncp genesis/templates/web/index-template.html index.html\n")},
+		"genesis/CHECKLIST.md":  {Data: []byte("# Checklist\n")},
+		"genesis/templates/web/index-template.html": {Data: []byte("<html></html>\n")},
+		"genesis/templates/web/extra-template.js":   {Data: []byte("// unused\n")},
+	}
+}
+
+func testConfig() *Config {
+	config := DefaultConfig()
+	config.EntryPoints = nil
+	config.Strict = false
+	return config
+}
+
+func TestDefaultConfig_NotStrictInCI(t *testing.T) {
+	t.Setenv("CI", "true")
+	if DefaultConfig().Strict {
+		t.Error("DefaultConfig().Strict = true with CI set, want false")
+	}
+}
+
+func TestRun_FS(t *testing.T) {
+	var logs bytes.Buffer
+	report, err := Run(context.Background(), Options{
+		Config: testConfig(),
+		FS:     testTree(),
+		Logger: slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range report.Findings {
+		got = append(got, string(f.Severity)+" "+f.Rule+" "+f.File)
+	}
+	want := []string{
+		"error broken_link README.md",
+		"error orphaned_file templates/web/extra-template.js",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+	if report.Valid() {
+		t.Error("Valid() = true, want false")
+	}
+	if len(report.TemplateFiles) != 2 {
+		t.Errorf("TemplateFiles = %v, want 2 templates", report.TemplateFiles)
+	}
+	if !strings.Contains(logs.String(), "found template files") {
+		t.Errorf("logger got %q, want progress messages", logs.String())
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := Run(ctx, Options{Config: testConfig(), FS: testTree()})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+	if report == nil {
+		t.Fatal("Run() report = nil, want the findings so far")
+	}
+}
+
+func TestRun_NoStdout(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	t.Cleanup(func() { os.Stdout = stdout })
+
+	config := testConfig()
+	config.Verbose = true
+	_, runErr := Run(context.Background(), Options{Config: config, FS: testTree()})
+
+	os.Stdout = stdout
+	_ = w.Close()
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatalf("Run() error = %v", runErr)
+	}
+	if len(out) > 0 {
+		t.Errorf("Run() wrote to stdout: %q", out)
+	}
+}